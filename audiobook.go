@@ -0,0 +1,286 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Author is one of the authors of an audiobook.
+type Author struct {
+	// The name of the author.
+	Name string `json:"name"`
+}
+
+// Narrator is one of the narrators of an audiobook.
+type Narrator struct {
+	// The name of the narrator.
+	Name string `json:"name"`
+}
+
+// SimpleAudiobook contains basic data about an audiobook.
+type SimpleAudiobook struct {
+	// The authors of the audiobook.
+	Authors []Author `json:"authors"`
+
+	// A list of the countries in which the audiobook can be played,
+	// identified by their ISO 3166-1 alpha-2 code.
+	AvailableMarkets []string `json:"available_markets"`
+
+	// The copyright statements of the audiobook.
+	Copyrights []Copyright `json:"copyrights"`
+
+	// A description of the audiobook, with HTML tags stripped.
+	Description string `json:"description"`
+
+	// A description of the audiobook, which may contain HTML tags.
+	HTMLDescription string `json:"html_description"`
+
+	// The edition of the audiobook, such as "Unabridged".
+	Edition string `json:"edition"`
+
+	// Whether or not the audiobook has explicit content
+	// (true = yes it does; false = no it does not OR unknown).
+	Explicit bool `json:"explicit"`
+
+	// Known external URLs for this audiobook.
+	ExternalURLs map[string]string `json:"external_urls"`
+
+	// A link to the Web API endpoint providing full details
+	// of the audiobook.
+	Href string `json:"href"`
+
+	// The Spotify ID for the audiobook.
+	ID ID `json:"id"`
+
+	// The cover art for the audiobook in various sizes,
+	// widest first.
+	Images []Image `json:"images"`
+
+	// A list of the languages used in the audiobook, identified by
+	// their ISO 639 code.
+	Languages []string `json:"languages"`
+
+	// The media type of the audiobook.
+	MediaType string `json:"media_type"`
+
+	// The name of the audiobook.
+	Name string `json:"name"`
+
+	// The narrators of the audiobook.
+	Narrators []Narrator `json:"narrators"`
+
+	// The publisher of the audiobook.
+	Publisher string `json:"publisher"`
+
+	// The number of chapters in the audiobook.
+	TotalChapters int `json:"total_chapters"`
+
+	// The object type: "audiobook".
+	Type string `json:"type"`
+
+	// The Spotify URI for the audiobook.
+	URI URI `json:"uri"`
+}
+
+// FullAudiobook contains full data about an audiobook.
+type FullAudiobook struct {
+	SimpleAudiobook
+
+	// A page of the audiobook's chapters.
+	Chapters ChapterPage `json:"chapters"`
+}
+
+// Chapter contains data about a chapter of an audiobook.
+type Chapter struct {
+	// A URL to a 30 second preview (MP3 format) of the chapter.
+	AudioPreviewURL string `json:"audio_preview_url"`
+
+	// A list of the countries in which the chapter can be played,
+	// identified by their ISO 3166-1 alpha-2 code.
+	AvailableMarkets []string `json:"available_markets"`
+
+	// The number of the chapter in the audiobook, starting at 0.
+	ChapterNumber int `json:"chapter_number"`
+
+	// A description of the chapter, with HTML tags stripped.
+	Description string `json:"description"`
+
+	// A description of the chapter, which may contain HTML tags.
+	HTMLDescription string `json:"html_description"`
+
+	// The chapter length in milliseconds.
+	Duration int `json:"duration_ms"`
+
+	// Whether or not the chapter has explicit content
+	// (true = yes it does; false = no it does not OR unknown).
+	Explicit bool `json:"explicit"`
+
+	// Known external URLs for this chapter.
+	ExternalURLs map[string]string `json:"external_urls"`
+
+	// A link to the Web API endpoint providing full details of the chapter.
+	Href string `json:"href"`
+
+	// The Spotify ID for the chapter.
+	ID ID `json:"id"`
+
+	// The cover art for the chapter in various sizes, widest first.
+	Images []Image `json:"images"`
+
+	// True if the chapter is playable in the given market.
+	// Otherwise false.
+	IsPlayable bool `json:"is_playable"`
+
+	// A list of the languages used in the chapter, identified by their ISO 639 code.
+	Languages []string `json:"languages"`
+
+	// The name of the chapter.
+	Name string `json:"name"`
+
+	// The date the chapter was first released, for example
+	// "1981-12-15". Depending on the precision, it might
+	// be shown as "1981" or "1981-12".
+	ReleaseDate string `json:"release_date"`
+
+	// The precision with which release_date value is known:
+	// "year", "month", or "day".
+	ReleaseDatePrecision string `json:"release_date_precision"`
+
+	// The user’s most recent position in the chapter. Set if the
+	// supplied access token is a user token and has the scope
+	// user-read-playback-position.
+	ResumePoint ResumePointObject `json:"resume_point"`
+
+	// The object type: "chapter".
+	Type string `json:"type"`
+
+	// The Spotify URI for the chapter.
+	URI URI `json:"uri"`
+
+	// The audiobook the chapter belongs to.  It is only set by
+	// GetChapter and GetChapters.
+	Audiobook *SimpleAudiobook `json:"audiobook,omitempty"`
+}
+
+// GetAudiobook gets Spotify catalog information for a single audiobook,
+// given its Spotify ID.  Audiobooks are only available in some markets.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/get-an-audiobook
+//
+// Supported options: Market
+func (c *Client) GetAudiobook(ctx context.Context, id ID, opts ...RequestOption) (*FullAudiobook, error) {
+	spotifyURL := c.baseURL + "audiobooks/" + string(id)
+
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result FullAudiobook
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAudiobooks gets Spotify catalog information for multiple audiobooks, given
+// their Spotify IDs.  It supports up to 50 IDs in a single call.  Audiobooks are
+// returned in the order requested.  If an audiobook is not found, or isn't
+// available in the given market, that position in the result slice will be nil.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/get-multiple-audiobooks
+//
+// Supported options: Market
+func (c *Client) GetAudiobooks(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullAudiobook, error) {
+	if len(ids) > 50 {
+		return nil, errors.New("spotify: exceeded maximum number of audiobooks")
+	}
+	params := processOptions(opts...).urlParams
+	params.Set("ids", strings.Join(toStringSlice(ids), ","))
+
+	spotifyURL := fmt.Sprintf("%saudiobooks?%s", c.baseURL, params.Encode())
+
+	var a struct {
+		Audiobooks []*FullAudiobook `json:"audiobooks"`
+	}
+
+	err := c.get(ctx, spotifyURL, &a)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.Audiobooks, nil
+}
+
+// GetAudiobookChapters gets a page of the chapters of an audiobook.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/get-audiobook-chapters
+//
+// Supported options: Limit, Market, Offset
+func (c *Client) GetAudiobookChapters(ctx context.Context, id ID, opts ...RequestOption) (*ChapterPage, error) {
+	spotifyURL := c.baseURL + "audiobooks/" + string(id) + "/chapters"
+
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result ChapterPage
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetChapter gets Spotify catalog information for a single chapter of an
+// audiobook, given its Spotify ID.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/get-a-chapter
+//
+// Supported options: Market
+func (c *Client) GetChapter(ctx context.Context, id ID, opts ...RequestOption) (*Chapter, error) {
+	spotifyURL := c.baseURL + "chapters/" + string(id)
+
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result Chapter
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetChapters gets Spotify catalog information for multiple chapters, given
+// their Spotify IDs.  It supports up to 50 IDs in a single call.  Chapters are
+// returned in the order requested.  If a chapter is not found, or isn't
+// available in the given market, that position in the result slice will be nil.
+// API reference: https://developer.spotify.com/documentation/web-api/reference/get-several-chapters
+//
+// Supported options: Market
+func (c *Client) GetChapters(ctx context.Context, ids []ID, opts ...RequestOption) ([]*Chapter, error) {
+	if len(ids) > 50 {
+		return nil, errors.New("spotify: exceeded maximum number of chapters")
+	}
+	params := processOptions(opts...).urlParams
+	params.Set("ids", strings.Join(toStringSlice(ids), ","))
+
+	spotifyURL := fmt.Sprintf("%schapters?%s", c.baseURL, params.Encode())
+
+	var ch struct {
+		Chapters []*Chapter `json:"chapters"`
+	}
+
+	err := c.get(ctx, spotifyURL, &ch)
+	if err != nil {
+		return nil, err
+	}
+
+	return ch.Chapters, nil
+}
@@ -0,0 +1,180 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const chapterJSON = `{
+  "chapter_number": 1,
+  "description": "We are going to Arrakis.",
+  "duration_ms": 1391000,
+  "id": "0D5wENdkdwbqlrHoaJ9g29",
+  "name": "Chapter 1",
+  "release_date": "1965-08-01",
+  "release_date_precision": "day",
+  "type": "chapter",
+  "uri": "spotify:chapter:0D5wENdkdwbqlrHoaJ9g29"
+}`
+
+const audiobookJSON = `{
+  "authors": [{"name": "Frank Herbert"}],
+  "edition": "Unabridged",
+  "id": "7iHfbu1YPACw6oZPAFJtqe",
+  "name": "Dune",
+  "narrators": [{"name": "Scott Brick"}, {"name": "Orlagh Cassidy"}],
+  "publisher": "Macmillan Audio",
+  "total_chapters": 2,
+  "type": "audiobook",
+  "uri": "spotify:audiobook:7iHfbu1YPACw6oZPAFJtqe",
+  "chapters": {
+    "href": "https://api.spotify.com/v1/audiobooks/7iHfbu1YPACw6oZPAFJtqe/chapters?offset=0&limit=1",
+    "items": [` + chapterJSON + `],
+    "limit": 1,
+    "next": "https://api.spotify.com/v1/audiobooks/7iHfbu1YPACw6oZPAFJtqe/chapters?offset=1&limit=1",
+    "offset": 0,
+    "previous": null,
+    "total": 2
+  }
+}`
+
+func TestGetAudiobook(t *testing.T) {
+	client, server := testClientString(http.StatusOK, audiobookJSON, func(req *http.Request) {
+		if req.URL.Path != "/audiobooks/7iHfbu1YPACw6oZPAFJtqe" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+		if m := req.URL.Query().Get("market"); m != "US" {
+			t.Errorf("Expected market US, got %q", m)
+		}
+	})
+	defer server.Close()
+
+	a, err := client.GetAudiobook(context.Background(), "7iHfbu1YPACw6oZPAFJtqe", Market("US"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "Dune" || a.Edition != "Unabridged" || len(a.Narrators) != 2 {
+		t.Errorf("Invalid data: %+v", a.SimpleAudiobook)
+	}
+	if a.Chapters.Total != 2 || len(a.Chapters.Chapters) != 1 || a.Chapters.Chapters[0].Duration != 1391000 {
+		t.Errorf("Invalid chapters: %+v", a.Chapters)
+	}
+}
+
+func TestGetAudiobooks(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"audiobooks": [`+audiobookJSON+`, null]}`, func(req *http.Request) {
+		if ids := req.URL.Query().Get("ids"); ids != "7iHfbu1YPACw6oZPAFJtqe,0000000000000000000000" {
+			t.Errorf("Unexpected ids %q", ids)
+		}
+	})
+	defer server.Close()
+
+	audiobooks, err := client.GetAudiobooks(context.Background(), []ID{"7iHfbu1YPACw6oZPAFJtqe", "0000000000000000000000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(audiobooks) != 2 || audiobooks[0] == nil || audiobooks[1] != nil {
+		t.Fatalf("Unexpected audiobooks %v", audiobooks)
+	}
+
+	if _, err := client.GetAudiobooks(context.Background(), make([]ID, 51)); err == nil {
+		t.Error("Expected an error for more than 50 IDs")
+	}
+}
+
+func TestGetAudiobookChapters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audiobooks/7iHfbu1YPACw6oZPAFJtqe/chapters" {
+			http.NotFound(w, r)
+			return
+		}
+		next := `"` + "http://" + r.Host + r.URL.Path + `?offset=1&limit=1"`
+		chapter := chapterJSON
+		if r.URL.Query().Get("offset") == "1" {
+			next = "null"
+			chapter = `{"chapter_number": 2, "id": "1E5wENdkdwbqlrHoaJ9g30", "name": "Chapter 2"}`
+		}
+		_, _ = w.Write([]byte(`{"items": [` + chapter + `], "limit": 1, "next": ` + next + `, "total": 2}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL + "/v1/"))
+	page, err := client.GetAudiobookChapters(context.Background(), "7iHfbu1YPACw6oZPAFJtqe", Limit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Chapters) != 1 || page.Chapters[0].ChapterNumber != 1 {
+		t.Fatalf("Unexpected first page %+v", page.Chapters)
+	}
+	if err := client.NextPage(context.Background(), page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Chapters) != 1 || page.Chapters[0].Name != "Chapter 2" {
+		t.Errorf("Unexpected second page %+v", page.Chapters)
+	}
+	if err := client.NextPage(context.Background(), page); err != ErrNoMorePages {
+		t.Errorf("Expected ErrNoMorePages, got %v", err)
+	}
+}
+
+func TestGetChapter(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{
+  "id": "0D5wENdkdwbqlrHoaJ9g29",
+  "name": "Chapter 1",
+  "audiobook": {"id": "7iHfbu1YPACw6oZPAFJtqe", "name": "Dune"}
+}`, func(req *http.Request) {
+		if req.URL.Path != "/chapters/0D5wENdkdwbqlrHoaJ9g29" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	c, err := client.GetChapter(context.Background(), "0D5wENdkdwbqlrHoaJ9g29")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Audiobook == nil || c.Audiobook.Name != "Dune" {
+		t.Errorf("Expected the chapter's audiobook, got %+v", c.Audiobook)
+	}
+}
+
+func TestGetChapters(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"chapters": [`+chapterJSON+`]}`)
+	defer server.Close()
+
+	chapters, err := client.GetChapters(context.Background(), []ID{"0D5wENdkdwbqlrHoaJ9g29"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chapters) != 1 || chapters[0].ID != "0D5wENdkdwbqlrHoaJ9g29" {
+		t.Errorf("Unexpected chapters %v", chapters)
+	}
+	if chapters[0].Audiobook != nil {
+		t.Error("Expected no audiobook")
+	}
+}
+
+func TestCurrentUsersAudiobooks(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{
+  "href": "https://api.spotify.com/v1/me/audiobooks?offset=0&limit=20",
+  "items": [{"id": "7iHfbu1YPACw6oZPAFJtqe", "name": "Dune", "total_chapters": 2}],
+  "limit": 20,
+  "offset": 0,
+  "total": 1
+}`, func(req *http.Request) {
+		if req.URL.Path != "/me/audiobooks" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	page, err := client.CurrentUsersAudiobooks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Audiobooks) != 1 || page.Audiobooks[0].TotalChapters != 2 {
+		t.Errorf("Unexpected page %+v", page)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"strings"
 
+	"github.com/conradludgate/spotify/v2/auth/scope"
 	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 const (
@@ -23,47 +26,50 @@ const (
 // permissions the user is asked to grant.
 const (
 	// ScopeImageUpload seeks permission to upload images to Spotify on your behalf.
-	ScopeImageUpload = "ugc-image-upload"
+	ScopeImageUpload = scope.ImageUpload
 	// ScopePlaylistReadPrivate seeks permission to read
 	// a user's private playlists.
-	ScopePlaylistReadPrivate = "playlist-read-private"
+	ScopePlaylistReadPrivate = scope.PlaylistReadPrivate
 	// ScopePlaylistModifyPublic seeks write access
 	// to a user's public playlists.
-	ScopePlaylistModifyPublic = "playlist-modify-public"
+	ScopePlaylistModifyPublic = scope.PlaylistModifyPublic
 	// ScopePlaylistModifyPrivate seeks write access to
 	// a user's private playlists.
-	ScopePlaylistModifyPrivate = "playlist-modify-private"
+	ScopePlaylistModifyPrivate = scope.PlaylistModifyPrivate
 	// ScopePlaylistReadCollaborative seeks permission to
 	// access a user's collaborative playlists.
-	ScopePlaylistReadCollaborative = "playlist-read-collaborative"
+	ScopePlaylistReadCollaborative = scope.PlaylistReadCollaborative
 	// ScopeUserFollowModify seeks write/delete access to
 	// the list of artists and other users that a user follows.
-	ScopeUserFollowModify = "user-follow-modify"
+	ScopeUserFollowModify = scope.UserFollowModify
 	// ScopeUserFollowRead seeks read access to the list of
 	// artists and other users that a user follows.
-	ScopeUserFollowRead = "user-follow-read"
+	ScopeUserFollowRead = scope.UserFollowRead
 	// ScopeUserLibraryModify seeks write/delete access to a
 	// user's "Your Music" library.
-	ScopeUserLibraryModify = "user-library-modify"
+	ScopeUserLibraryModify = scope.UserLibraryModify
 	// ScopeUserLibraryRead seeks read access to a user's "Your Music" library.
-	ScopeUserLibraryRead = "user-library-read"
+	ScopeUserLibraryRead = scope.UserLibraryRead
 	// ScopeUserReadPrivate seeks read access to a user's
 	// subsription details (type of user account).
-	ScopeUserReadPrivate = "user-read-private"
+	ScopeUserReadPrivate = scope.UserReadPrivate
 	// ScopeUserReadEmail seeks read access to a user's email address.
-	ScopeUserReadEmail = "user-read-email"
+	ScopeUserReadEmail = scope.UserReadEmail
 	// ScopeUserReadCurrentlyPlaying seeks read access to a user's currently playing track
-	ScopeUserReadCurrentlyPlaying = "user-read-currently-playing"
+	ScopeUserReadCurrentlyPlaying = scope.UserReadCurrentlyPlaying
 	// ScopeUserReadPlaybackState seeks read access to the user's current playback state
-	ScopeUserReadPlaybackState = "user-read-playback-state"
+	ScopeUserReadPlaybackState = scope.UserReadPlaybackState
 	// ScopeUserModifyPlaybackState seeks write access to the user's current playback state
-	ScopeUserModifyPlaybackState = "user-modify-playback-state"
+	ScopeUserModifyPlaybackState = scope.UserModifyPlaybackState
 	// ScopeUserReadRecentlyPlayed allows access to a user's recently-played songs
-	ScopeUserReadRecentlyPlayed = "user-read-recently-played"
+	ScopeUserReadRecentlyPlayed = scope.UserReadRecentlyPlayed
 	// ScopeUserTopRead seeks read access to a user's top tracks and artists
-	ScopeUserTopRead = "user-top-read"
+	ScopeUserTopRead = scope.UserTopRead
+	// ScopeUserReadPlaybackPosition seeks read access to a user's playback
+	// position in the episodes they listened to.
+	ScopeUserReadPlaybackPosition = scope.UserReadPlaybackPosition
 	// ScopeStreaming seeks permission to play music and control playback on your other devices.
-	ScopeStreaming = "streaming"
+	ScopeStreaming = scope.Streaming
 )
 
 // Authenticator provides convenience functions for implementing the OAuth2 flow.
@@ -116,6 +122,15 @@ func WithRedirectURL(url string) AuthenticatorOption {
 	}
 }
 
+// WithEndpoint configures the URLs of the Spotify Accounts Service that the
+// authenticator uses, which default to AuthURL and TokenURL.  This is mostly
+// useful to test the authorization flow against a fake server.
+func WithEndpoint(endpoint oauth2.Endpoint) AuthenticatorOption {
+	return func(a *Authenticator) {
+		a.config.Endpoint = endpoint
+	}
+}
+
 // New creates an authenticator which is used to implement the OAuth2 authorization flow.
 //
 // By default, NewAuthenticator pulls your client ID and secret key from the SPOTIFY_ID and SPOTIFY_SECRET environment variables.
@@ -137,6 +152,12 @@ func New(opts ...AuthenticatorOption) *Authenticator {
 		opt(a)
 	}
 
+	// Clients without a secret use PKCE, and must identify
+	// themselves in the body of token requests.
+	if cfg.ClientSecret == "" && cfg.Endpoint.AuthStyle == oauth2.AuthStyleAutoDetect {
+		cfg.Endpoint.AuthStyle = oauth2.AuthStyleInParams
+	}
+
 	return a
 }
 
@@ -189,8 +210,44 @@ func (a Authenticator) Exchange(ctx context.Context, code string, opts ...oauth2
 	return a.config.Exchange(contextWithHTTPClient(ctx), code, opts...)
 }
 
+// RefreshToken uses the refresh token of token to obtain a new access token,
+// even if token hasn't expired yet.  Tokens obtained with PKCE are refreshed
+// without a client secret.
+func (a Authenticator) RefreshToken(ctx context.Context, token *oauth2.Token) (*oauth2.Token, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.New("spotify: token has no refresh token")
+	}
+	src := a.config.TokenSource(contextWithHTTPClient(ctx), &oauth2.Token{RefreshToken: token.RefreshToken})
+	return src.Token()
+}
+
 // Client creates a *http.Client that will use the specified access token for its API requests.
 // Combine this with spotify.HTTPClientOpt.
 func (a Authenticator) Client(ctx context.Context, token *oauth2.Token) *http.Client {
 	return a.config.Client(contextWithHTTPClient(ctx), token)
 }
+
+// ClientCredentialsClient creates a *http.Client that authenticates its requests
+// with an app token obtained through the client credentials flow, using the
+// authenticator's client ID and secret.  A new token is requested whenever the
+// previous one expires.  App tokens don't give access to any user's data.
+func (a Authenticator) ClientCredentialsClient(ctx context.Context) *http.Client {
+	cfg := &clientcredentials.Config{
+		ClientID:     a.config.ClientID,
+		ClientSecret: a.config.ClientSecret,
+		TokenURL:     a.config.Endpoint.TokenURL,
+		AuthStyle:    a.config.Endpoint.AuthStyle,
+	}
+	return cfg.Client(contextWithHTTPClient(ctx))
+}
+
+// GrantedScopes returns the scopes the user granted to token, as reported by
+// the Spotify Accounts Service when the token was issued.  It returns nil if
+// the token doesn't say.
+func GrantedScopes(token *oauth2.Token) []string {
+	scope, _ := token.Extra("scope").(string)
+	if scope == "" {
+		return nil
+	}
+	return strings.Fields(scope)
+}
@@ -0,0 +1,195 @@
+package spotifyauth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+	"github.com/conradludgate/spotify/v2/spotifytest"
+	"golang.org/x/oauth2"
+)
+
+const redirectURL = "http://localhost:8080/callback"
+
+func newAccounts(t *testing.T, secret string) *spotifytest.AccountsServer {
+	accounts := spotifytest.NewAccountsServer()
+	t.Cleanup(accounts.Close)
+	accounts.AddClient("client-id", secret)
+	return accounts
+}
+
+// authorize sends the user to the authorization URL and returns the
+// request the accounts server redirects them to.
+func authorize(t *testing.T, a *spotifyauth.Authenticator, state string, opts ...oauth2.AuthCodeOption) *http.Request {
+	t.Helper()
+	return authorizeURL(t, a.AuthURL(state, opts...))
+}
+
+// authorizeURL sends the user to an authorization URL and returns the
+// request the accounts server redirects them to.
+func authorizeURL(t *testing.T, u string) *http.Request {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("Expected a redirect, got HTTP %d", resp.StatusCode)
+	}
+	return httptest.NewRequest("GET", resp.Header.Get("Location"), nil)
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	accounts.SetTokenLifetime(5 * time.Second)
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithRedirectURL(redirectURL),
+		spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+	ctx := context.Background()
+
+	if _, err := a.Token(ctx, "other-state", authorize(t, a, "state")); err == nil {
+		t.Error("Expected an error when the state doesn't match")
+	}
+
+	token, err := a.Token(ctx, "state", authorize(t, a, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scope, ok := accounts.Valid(token.AccessToken); !ok || scope != spotifyauth.ScopeUserReadPrivate {
+		t.Errorf("Expected a valid token with the requested scope, got %q", scope)
+	}
+	if token.RefreshToken == "" {
+		t.Error("Expected a refresh token")
+	}
+
+	// the token expires within the expiry delta of the oauth2 package,
+	// so the client refreshes it before its first request
+	var sent string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}))
+	defer api.Close()
+	resp, err := a.Client(ctx, token).Get(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if sent == token.AccessToken {
+		t.Error("Expected the token to be refreshed")
+	}
+	if _, ok := accounts.Valid(sent); !ok {
+		t.Errorf("Expected the refreshed token to be valid, got %q", sent)
+	}
+}
+
+func TestAuthorizationCodeErrors(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	ctx := context.Background()
+	newAuth := func(secret string) *spotifyauth.Authenticator {
+		return spotifyauth.New(
+			spotifyauth.WithClientID("client-id"),
+			spotifyauth.WithClientSecret(secret),
+			spotifyauth.WithRedirectURL(redirectURL),
+			spotifyauth.WithEndpoint(accounts.Endpoint()),
+		)
+	}
+
+	a := newAuth("wrong-secret")
+	if _, err := a.Token(ctx, "state", authorize(t, a, "state")); err == nil {
+		t.Error("Expected an error with the wrong client secret")
+	}
+
+	a = newAuth("client-secret")
+	accounts.DenyAuthorization(true)
+	_, err := a.Token(ctx, "state", authorize(t, a, "state"))
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Expected access_denied, got %v", err)
+	}
+	accounts.DenyAuthorization(false)
+
+	r := authorize(t, a, "state")
+	if _, err := a.Token(ctx, "state", r); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Token(ctx, "state", r); err == nil {
+		t.Error("Expected an error when reusing a code")
+	}
+
+	accounts.FailNext(http.StatusServiceUnavailable, "server_error")
+	_, err = a.Token(ctx, "state", authorize(t, a, "state"))
+	var rerr *oauth2.RetrieveError
+	if !errors.As(err, &rerr) || rerr.Response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the injected failure, got %v", err)
+	}
+}
+
+func TestClientCredentialsClient(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	ctx := context.Background()
+
+	var sent []string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	}))
+	defer api.Close()
+
+	client := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	).ClientCredentialsClient(ctx)
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(api.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if _, ok := accounts.Valid(sent[0]); !ok || sent[0] != sent[1] {
+		t.Errorf("Expected the same valid app token to be reused, got %q", sent)
+	}
+
+	client = spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("wrong-secret"),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	).ClientCredentialsClient(ctx)
+	if _, err := client.Get(api.URL); err == nil {
+		t.Error("Expected an error with the wrong client secret")
+	}
+}
+
+func TestGrantedScopes(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithRedirectURL(redirectURL),
+		spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate, spotifyauth.ScopeUserFollowRead),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+	token, err := a.Token(context.Background(), "state", authorize(t, a, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scopes := spotifyauth.GrantedScopes(token)
+	if len(scopes) != 2 || scopes[0] != spotifyauth.ScopeUserReadPrivate || scopes[1] != spotifyauth.ScopeUserFollowRead {
+		t.Errorf("Expected the requested scopes, got %v", scopes)
+	}
+	if scopes := spotifyauth.GrantedScopes(&oauth2.Token{AccessToken: "access"}); scopes != nil {
+		t.Errorf("Expected no scope, got %v", scopes)
+	}
+}
@@ -0,0 +1,268 @@
+package spotifyauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/oauth2"
+)
+
+// KeySize is the size in bytes of the keys used by EncryptedFileStore.
+const KeySize = 32
+
+// ErrUnknownKey is returned when a stored token was encrypted with a key
+// that the EncryptedFileStore doesn't have.
+var ErrUnknownKey = errors.New("spotify: token was encrypted with an unknown key")
+
+// GenerateKey returns a new random key for an EncryptedFileStore.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// KeyFromPassphrase derives a key for an EncryptedFileStore from a passphrase
+// with scrypt.  salt should be random, at least 16 bytes long, and kept along
+// with the store's configuration: it doesn't need to be secret, but the same
+// salt must be used every time the key is derived.
+func KeyFromPassphrase(passphrase string, salt []byte) ([]byte, error) {
+	if len(salt) < 16 {
+		return nil, errors.New("spotify: salt must be at least 16 bytes long")
+	}
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, KeySize)
+}
+
+// EncryptedFileStore is a TokenStore that keeps tokens encrypted on disk.
+//
+// It uses envelope encryption: every token is encrypted with AES-256-GCM
+// under a random data key of its own, and the data key is in turn encrypted
+// with the key the store was created with.  Each file records which key
+// protects its data key, so that the store's key can be rotated without
+// re-encrypting the tokens themselves.
+type EncryptedFileStore struct {
+	files *FileStore
+
+	// writeMu serializes the changes to the files, so that Rotate
+	// never writes back a token that Save replaced in the meantime.
+	writeMu sync.Mutex
+
+	mu      sync.RWMutex
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// envelope is the content of the file holding an encrypted token.
+type envelope struct {
+	// KeyID identifies the key that encrypted DataKey.
+	KeyID string `json:"key_id"`
+	// DataKey is the data key encrypted with the store's key,
+	// prefixed by its nonce.
+	DataKey []byte `json:"data_key"`
+	// Token is the JSON encoded token encrypted with
+	// the data key, prefixed by its nonce.
+	Token []byte `json:"token"`
+}
+
+// NewEncryptedFileStore returns an EncryptedFileStore that keeps its files in
+// dir, creating the directory if needed.  Tokens are saved encrypted with key,
+// which must be KeySize bytes long.  Tokens that were encrypted with one of
+// oldKeys can still be loaded, which is useful to finish an interrupted Rotate.
+func NewEncryptedFileStore(dir string, key []byte, oldKeys ...[]byte) (*EncryptedFileStore, error) {
+	files, err := NewFileStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &EncryptedFileStore{files: files, keys: make(map[string]cipher.AEAD)}
+	for _, k := range oldKeys {
+		if _, err := s.addKey(k); err != nil {
+			return nil, err
+		}
+	}
+	if s.current, err = s.addKey(key); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// keyID identifies a key without revealing it.
+func keyID(key []byte) string {
+	sum := sha256.Sum256(append([]byte("spotifyauth key id\x00"), key...))
+	return hex.EncodeToString(sum[:8])
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("spotify: key must be %d bytes long", KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *EncryptedFileStore) addKey(key []byte) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	id := keyID(key)
+	s.keys[id] = aead
+	return id, nil
+}
+
+// seal encrypts plaintext, binding it to additionalData,
+// and prefixes the result with its random nonce.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts data encrypted with seal.
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("spotify: encrypted data is too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// Load implements TokenStore.
+func (s *EncryptedFileStore) Load(key string) (*oauth2.Token, error) {
+	env, dataKey, err := s.open(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	// The token is bound to its key, so that files can't be swapped.
+	b, err := open(aead, env.Token, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("spotify: couldn't decrypt token: %w", err)
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, fmt.Errorf("spotify: couldn't decode token: %w", err)
+	}
+	return &token, nil
+}
+
+// open reads the envelope stored under key and decrypts its data key.
+func (s *EncryptedFileStore) open(key string) (*envelope, []byte, error) {
+	b, err := s.files.read(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return nil, nil, fmt.Errorf("spotify: couldn't decode encrypted token: %w", err)
+	}
+
+	s.mu.RLock()
+	kek, ok := s.keys[env.KeyID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, nil, ErrUnknownKey
+	}
+	dataKey, err := open(kek, env.DataKey, []byte(env.KeyID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("spotify: couldn't decrypt data key: %w", err)
+	}
+	return &env, dataKey, nil
+}
+
+// Save implements TokenStore.  A new data key is generated every time.
+func (s *EncryptedFileStore) Save(key string, token *oauth2.Token) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	dataKey, err := GenerateKey()
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+	env := envelope{}
+	if env.Token, err = seal(aead, b, []byte(key)); err != nil {
+		return err
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.write(key, &env, dataKey)
+}
+
+// write encrypts dataKey with the current key into env, and stores env under key.
+func (s *EncryptedFileStore) write(key string, env *envelope, dataKey []byte) error {
+	s.mu.RLock()
+	id, kek := s.current, s.keys[s.current]
+	s.mu.RUnlock()
+
+	var err error
+	env.KeyID = id
+	if env.DataKey, err = seal(kek, dataKey, []byte(id)); err != nil {
+		return err
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return s.files.write(key, b)
+}
+
+// Rotate makes newKey the key of the store, and encrypts the data keys of all
+// the stored tokens with it.  The tokens themselves are not re-encrypted, and
+// users don't need to authorize the application again.
+//
+// The previous key can still be used to load tokens until the store is
+// discarded.  If Rotate fails, it can be called again; a store created with
+// the previous key among its old keys can also finish the rotation.
+func (s *EncryptedFileStore) Rotate(newKey []byte) error {
+	s.mu.Lock()
+	id, err := s.addKey(newKey)
+	if err == nil {
+		s.current = id
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	keys, err := s.files.keys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		env, dataKey, err := s.open(key)
+		if err != nil {
+			return fmt.Errorf("spotify: couldn't rotate the key of token %q: %w", key, err)
+		}
+		if env.KeyID == id {
+			continue
+		}
+		if err := s.write(key, env, dataKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
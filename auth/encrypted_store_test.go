@@ -0,0 +1,264 @@
+package spotifyauth_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+	"golang.org/x/oauth2"
+)
+
+func newKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := spotifyauth.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	dir := t.TempDir()
+	key := newKey(t)
+	store, err := spotifyauth.NewEncryptedFileStore(dir, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Load("alice"); !errors.Is(err, spotifyauth.ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+
+	want := &oauth2.Token{AccessToken: "secret-access", RefreshToken: "secret-refresh", TokenType: "Bearer"}
+	if err := store.Save("alice", want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.Load("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 file in the store's directory, got %d", len(files))
+	}
+	b, err := ioutil.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte("secret")) || bytes.Contains(b, key) {
+		t.Errorf("Expected the token to be encrypted, got %s", b)
+	}
+
+	// Another store with the same key can read the token, but not one with another key.
+	same, err := spotifyauth.NewEncryptedFileStore(dir, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := same.Load("alice"); err != nil {
+		t.Errorf("Expected the token to be loaded with the same key, got %v", err)
+	}
+	other, err := spotifyauth.NewEncryptedFileStore(dir, newKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Load("alice"); !errors.Is(err, spotifyauth.ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey, got %v", err)
+	}
+}
+
+func TestEncryptedFileStoreSwappedFiles(t *testing.T) {
+	dir := t.TempDir()
+	store, err := spotifyauth.NewEncryptedFileStore(dir, newKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("alice", &oauth2.Token{AccessToken: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("bob", &oauth2.Token{AccessToken: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Copying bob's file over alice's must not give bob's token to alice.
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(files[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(files[0], b, 0600); err != nil {
+		t.Fatal(err)
+	}
+	_, err0 := store.Load("alice")
+	_, err1 := store.Load("bob")
+	if err0 == nil && err1 == nil {
+		t.Error("Expected a swapped file to fail to decrypt")
+	}
+}
+
+func TestEncryptedFileStoreRotate(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, newKey := newKey(t), newKey(t)
+	store, err := spotifyauth.NewEncryptedFileStore(dir, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := []string{"alice", "bob", "carol"}
+	for _, user := range users {
+		if err := store.Save(user, &oauth2.Token{AccessToken: "access-" + user, RefreshToken: "refresh-" + user}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.Rotate(newKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the new key is needed from now on.
+	rotated, err := spotifyauth.NewEncryptedFileStore(dir, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range users {
+		for _, s := range []*spotifyauth.EncryptedFileStore{store, rotated} {
+			token, err := s.Load(user)
+			if err != nil {
+				t.Fatalf("Couldn't load %s's token: %v", user, err)
+			}
+			if token.RefreshToken != "refresh-"+user {
+				t.Errorf("Expected refresh token %q, got %q", "refresh-"+user, token.RefreshToken)
+			}
+		}
+	}
+	old, err := spotifyauth.NewEncryptedFileStore(dir, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.Load("alice"); !errors.Is(err, spotifyauth.ErrUnknownKey) {
+		t.Errorf("Expected ErrUnknownKey with the old key, got %v", err)
+	}
+}
+
+func TestEncryptedFileStoreRotateWhileSaving(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, newKey := newKey(t), newKey(t)
+	store, err := spotifyauth.NewEncryptedFileStore(dir, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var users []string
+	for i := 0; i < 20; i++ {
+		user := fmt.Sprintf("user%d", i)
+		users = append(users, user)
+		if err := store.Save(user, &oauth2.Token{AccessToken: "stale"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for _, user := range users {
+			if err := store.Save(user, &oauth2.Token{AccessToken: "refreshed"}); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	if err := store.Rotate(newKey); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	rotated, err := spotifyauth.NewEncryptedFileStore(dir, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, user := range users {
+		token, err := rotated.Load(user)
+		if err != nil {
+			t.Fatalf("Couldn't load %s's token: %v", user, err)
+		}
+		if token.AccessToken != "refreshed" {
+			t.Errorf("Expected %s's refreshed token to be kept, got %q", user, token.AccessToken)
+		}
+	}
+}
+
+func TestEncryptedFileStoreOldKeys(t *testing.T) {
+	dir := t.TempDir()
+	oldKey, newKey := newKey(t), newKey(t)
+	store, err := spotifyauth.NewEncryptedFileStore(dir, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("alice", &oauth2.Token{AccessToken: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = spotifyauth.NewEncryptedFileStore(dir, newKey, oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load("alice"); err != nil {
+		t.Errorf("Expected the token to be loaded with an old key, got %v", err)
+	}
+	if err := store.Save("bob", &oauth2.Token{AccessToken: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	onlyNew, err := spotifyauth.NewEncryptedFileStore(dir, newKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := onlyNew.Load("bob"); err != nil {
+		t.Errorf("Expected new tokens to be saved with the new key, got %v", err)
+	}
+}
+
+func TestEncryptedFileStoreInvalidKey(t *testing.T) {
+	if _, err := spotifyauth.NewEncryptedFileStore(t.TempDir(), []byte("too short")); err == nil {
+		t.Error("Expected an error for a short key")
+	}
+}
+
+func TestKeyFromPassphrase(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	k1, err := spotifyauth.KeyFromPassphrase("correct horse battery staple", salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(k1) != spotifyauth.KeySize {
+		t.Errorf("Expected a %d byte key, got %d", spotifyauth.KeySize, len(k1))
+	}
+	k2, err := spotifyauth.KeyFromPassphrase("correct horse battery staple", salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1, k2) {
+		t.Error("Expected the same passphrase and salt to derive the same key")
+	}
+	k3, err := spotifyauth.KeyFromPassphrase("correct horse battery staple", []byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(k1, k3) {
+		t.Error("Expected another salt to derive another key")
+	}
+	if _, err := spotifyauth.KeyFromPassphrase("passphrase", []byte("short")); err == nil {
+		t.Error("Expected an error for a short salt")
+	}
+}
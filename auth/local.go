@@ -0,0 +1,178 @@
+package spotifyauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultLoginTimeout is how long LoginLocal waits for the user to log in,
+// unless LocalLoginOptions.Timeout is set.
+const defaultLoginTimeout = 5 * time.Minute
+
+// LocalLoginOptions configures LoginLocal.
+type LocalLoginOptions struct {
+	// Addr is the address the redirect server listens on, such as
+	// "127.0.0.1:8080".  Defaults to the host of the authenticator's redirect
+	// URL if it is set, or to a random port of 127.0.0.1 otherwise.
+	Addr string
+	// Path is the path of the redirect URL.  Defaults to the path of the
+	// authenticator's redirect URL if it is set, or to "/callback" otherwise.
+	Path string
+	// OpenURL is called with the URL the user must visit to log in, once the
+	// redirect server is ready.  It may open the URL in a browser, or show it
+	// to the user.  Defaults to printing the URL to the standard error.
+	OpenURL func(url string) error
+	// Timeout is how long to wait for the user to log in.
+	// Defaults to 5 minutes; a negative value waits forever.
+	Timeout time.Duration
+	// AuthURLOptions are passed when creating the URL to visit, for example
+	// ShowDialog.
+	AuthURLOptions []oauth2.AuthCodeOption
+}
+
+// LoginLocal runs the authorization code flow for applications running on
+// the user's machine, such as command line tools.  It starts an HTTP server on
+// a loopback address to receive the redirect, asks the user to log in with
+// OpenURL, exchanges the code and shuts the server down.  A random state is
+// generated to protect the user from CSRF attacks, and PKCE is used when the
+// authenticator has no client secret.
+//
+// The redirect URL, such as http://127.0.0.1:8080/callback, must be registered
+// in your Spotify developer account.  LoginLocal returns when the user logs in
+// or refuses to, when the timeout expires or when ctx is done.
+func (a Authenticator) LoginLocal(ctx context.Context, opts LocalLoginOptions) (*oauth2.Token, error) {
+	addr, path := opts.Addr, opts.Path
+	var redirect *url.URL
+	if addr == "" && a.config.RedirectURL != "" {
+		u, err := url.Parse(a.config.RedirectURL)
+		if err != nil {
+			return nil, fmt.Errorf("spotify: invalid redirect URL: %w", err)
+		}
+		if u.Scheme != "http" || u.Port() == "" {
+			return nil, errors.New("spotify: the redirect URL must be an http URL with a port to log in locally")
+		}
+		addr = u.Host
+		if path == "" {
+			path = u.Path
+		}
+		redirect = &url.URL{Scheme: "http", Host: u.Host}
+	}
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	if path == "" {
+		path = "/callback"
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultLoginTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if redirect == nil {
+		redirect = &url.URL{Scheme: "http", Host: ln.Addr().String()}
+	}
+	redirect.Path = path
+
+	// The redirect URL may differ from the authenticator's.
+	cfg := *a.config
+	cfg.RedirectURL = redirect.String()
+	a.config = &cfg
+
+	state, err := randomState()
+	if err != nil {
+		ln.Close()
+		return nil, err
+	}
+	var authURL string
+	var tokenOpts []oauth2.AuthCodeOption
+	if cfg.ClientSecret == "" {
+		verifier, err := GenerateVerifier()
+		if err != nil {
+			ln.Close()
+			return nil, err
+		}
+		authURL = a.AuthURLWithPKCE(state, verifier, opts.AuthURLOptions...)
+		tokenOpts = append(tokenOpts, VerifierOption(verifier))
+	} else {
+		authURL = a.AuthURL(state, opts.AuthURLOptions...)
+	}
+
+	type result struct {
+		token *oauth2.Token
+		err   error
+	}
+	results := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Ignore requests that don't belong to this login,
+		// without giving up on it.
+		if r.URL.Query().Get("state") != state {
+			http.Error(w, "Invalid state", http.StatusBadRequest)
+			return
+		}
+		token, err := a.Token(ctx, state, r, tokenOpts...)
+		if err != nil {
+			http.Error(w, "Login failed: "+err.Error(), http.StatusForbidden)
+		} else {
+			fmt.Fprintln(w, "Login completed! You can close this window.")
+		}
+		select {
+		case results <- result{token, err}:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(ln)
+	defer func() {
+		// Let the browser receive the response before stopping.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}()
+
+	open := opts.OpenURL
+	if open == nil {
+		open = func(url string) error {
+			_, err := fmt.Fprintln(os.Stderr, "Please log in to Spotify by visiting the following page in your browser:", url)
+			return err
+		}
+	}
+	if err := open(authURL); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-results:
+		return res.token, res.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("spotify: login wasn't completed: %w", ctx.Err())
+	}
+}
+
+// randomState returns a state that can't be guessed by an attacker.
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
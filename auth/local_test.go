@@ -0,0 +1,184 @@
+package spotifyauth_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+)
+
+// visit returns an OpenURL function that follows the authorization URL like
+// a browser would, and reports the status of the last response on status.
+func visit(t *testing.T, status chan<- int) func(string) error {
+	return func(u string) error {
+		go func() {
+			resp, err := http.Get(u)
+			if err != nil {
+				t.Error(err)
+				status <- 0
+				return
+			}
+			_, _ = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			status <- resp.StatusCode
+		}()
+		return nil
+	}
+}
+
+func TestLoginLocal(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+
+	var authURL string
+	status := make(chan int, 1)
+	token, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{
+		OpenURL: func(u string) error {
+			authURL = u
+			return visit(t, status)(u)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := <-status; code != http.StatusOK {
+		t.Errorf("Expected the browser to get HTTP 200, got %d", code)
+	}
+	if scope, ok := accounts.Valid(token.AccessToken); !ok || scope != spotifyauth.ScopeUserReadPrivate {
+		t.Errorf("Expected a valid token with the requested scope, got %q", scope)
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("state") == "" {
+		t.Error("Expected a random state")
+	}
+	if q.Get("code_challenge") != "" {
+		t.Error("Expected PKCE not to be used with a client secret")
+	}
+	if redirect := q.Get("redirect_uri"); !strings.HasPrefix(redirect, "http://127.0.0.1:") || !strings.HasSuffix(redirect, "/callback") {
+		t.Errorf("Unexpected redirect URI %q", redirect)
+	}
+}
+
+func TestLoginLocalPKCE(t *testing.T) {
+	accounts := newAccounts(t, "")
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret(""),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+
+	var challenge string
+	status := make(chan int, 1)
+	token, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{
+		Path: "/done",
+		OpenURL: func(u string) error {
+			parsed, _ := url.Parse(u)
+			challenge = parsed.Query().Get("code_challenge")
+			return visit(t, status)(u)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-status
+	if challenge == "" {
+		t.Error("Expected PKCE to be used without a client secret")
+	}
+	if _, ok := accounts.Valid(token.AccessToken); !ok {
+		t.Error("Expected a valid token")
+	}
+}
+
+func TestLoginLocalDenied(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	accounts.DenyAuthorization(true)
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+
+	status := make(chan int, 1)
+	_, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{OpenURL: visit(t, status)})
+	if err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("Expected access_denied, got %v", err)
+	}
+	if code := <-status; code != http.StatusForbidden {
+		t.Errorf("Expected the browser to get HTTP 403, got %d", code)
+	}
+}
+
+func TestLoginLocalIgnoresOtherStates(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+
+	status := make(chan int, 2)
+	token, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{
+		OpenURL: func(u string) error {
+			parsed, _ := url.Parse(u)
+			redirect := parsed.Query().Get("redirect_uri")
+			resp, err := http.Get(redirect + "?state=forged&code=forged")
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			status <- resp.StatusCode
+			return visit(t, status)(u)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := <-status; code != http.StatusBadRequest {
+		t.Errorf("Expected a forged state to be rejected, got HTTP %d", code)
+	}
+	<-status
+	if _, ok := accounts.Valid(token.AccessToken); !ok {
+		t.Error("Expected a valid token")
+	}
+}
+
+func TestLoginLocalTimeout(t *testing.T) {
+	a := spotifyauth.New(spotifyauth.WithClientID("client-id"), spotifyauth.WithClientSecret("client-secret"))
+	_, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{
+		Timeout: 50 * time.Millisecond,
+		OpenURL: func(string) error { return nil },
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the login to time out, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = a.LoginLocal(ctx, spotifyauth.LocalLoginOptions{OpenURL: func(string) error { return nil }})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the login to be canceled, got %v", err)
+	}
+}
+
+func TestLoginLocalRedirectURL(t *testing.T) {
+	a := spotifyauth.New(spotifyauth.WithRedirectURL("https://example.com/callback"))
+	if _, err := a.LoginLocal(context.Background(), spotifyauth.LocalLoginOptions{}); err == nil {
+		t.Error("Expected an error for a redirect URL that isn't local")
+	}
+}
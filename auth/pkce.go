@@ -0,0 +1,56 @@
+package spotifyauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"golang.org/x/oauth2"
+)
+
+// Applications that can't keep a client secret, such as desktop and command
+// line tools, authenticate with the authorization code flow extended with
+// PKCE (https://tools.ietf.org/html/rfc7636).  A new verifier is generated for
+// every authorization request and kept until the code is exchanged:
+//
+//	verifier, err := spotifyauth.GenerateVerifier()
+//	url := auth.AuthURLWithPKCE(state, verifier)
+//
+//	// then, in the redirect handler:
+//	token, err := auth.Token(ctx, state, r, spotifyauth.VerifierOption(verifier))
+//
+// Authenticators created without a client secret send their client ID in
+// the body of token requests, as required to refresh PKCE tokens.
+
+// GenerateVerifier returns a new PKCE code verifier made of 32
+// cryptographically random bytes.
+func GenerateVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// S256Challenge returns the code challenge derived from verifier
+// with the S256 method.
+func S256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// AuthURLWithPKCE is like AuthURL, but it also sends the S256 code challenge
+// derived from verifier.  The same verifier must then be passed to Token or
+// Exchange with VerifierOption.
+func (a Authenticator) AuthURLWithPKCE(state, verifier string, opts ...oauth2.AuthCodeOption) string {
+	opts = append(opts,
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("code_challenge", S256Challenge(verifier)),
+	)
+	return a.AuthURL(state, opts...)
+}
+
+// VerifierOption passes the PKCE code verifier to Token or Exchange.
+func VerifierOption(verifier string) oauth2.AuthCodeOption {
+	return oauth2.SetAuthURLParam("code_verifier", verifier)
+}
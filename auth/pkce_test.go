@@ -0,0 +1,75 @@
+package spotifyauth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+	"golang.org/x/oauth2"
+)
+
+func TestS256Challenge(t *testing.T) {
+	// from RFC 7636, appendix B
+	got := spotifyauth.S256Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestGenerateVerifier(t *testing.T) {
+	a, err := spotifyauth.GenerateVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := spotifyauth.GenerateVerifier()
+	if len(a) < 43 || len(a) > 128 {
+		t.Errorf("Verifier has invalid length %d", len(a))
+	}
+	if a == b {
+		t.Error("Expected different verifiers")
+	}
+}
+
+func TestPKCEFlow(t *testing.T) {
+	accounts := newAccounts(t, "")
+	accounts.SetTokenLifetime(5 * time.Second)
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret(""),
+		spotifyauth.WithRedirectURL(redirectURL),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+	ctx := context.Background()
+
+	verifier, err := spotifyauth.GenerateVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := authorize(t, a, "state")
+	if _, err := a.Token(ctx, "state", r); err == nil {
+		t.Error("Expected an error without a client secret or a verifier")
+	}
+
+	r = authorize(t, a, "state", oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+		oauth2.SetAuthURLParam("code_challenge", spotifyauth.S256Challenge(verifier)))
+	other, _ := spotifyauth.GenerateVerifier()
+	if _, err := a.Token(ctx, "state", r, spotifyauth.VerifierOption(other)); err == nil {
+		t.Error("Expected an error with the wrong verifier")
+	}
+
+	u := a.AuthURLWithPKCE("state", verifier)
+	token, err := a.Token(ctx, "state", authorizeURL(t, u), spotifyauth.VerifierOption(verifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := a.RefreshToken(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := accounts.Valid(refreshed.AccessToken); !ok || refreshed.AccessToken == token.AccessToken {
+		t.Error("Expected a new valid access token")
+	}
+}
@@ -0,0 +1,57 @@
+// Package scope lists the authorization scopes of the Spotify Web API.
+//
+// It has no dependencies, so that packages that only need the names of the
+// scopes, such as spotify for its scope checks, don't depend on spotifyauth.
+// spotifyauth has the same constants, with a Scope prefix.
+package scope
+
+// Scopes let you specify exactly which types of data your application wants to access.
+// The set of scopes you pass in your authentication request determines what access the
+// permissions the user is asked to grant.
+const (
+	// ImageUpload seeks permission to upload images to Spotify on your behalf.
+	ImageUpload = "ugc-image-upload"
+	// PlaylistReadPrivate seeks permission to read
+	// a user's private playlists.
+	PlaylistReadPrivate = "playlist-read-private"
+	// PlaylistModifyPublic seeks write access
+	// to a user's public playlists.
+	PlaylistModifyPublic = "playlist-modify-public"
+	// PlaylistModifyPrivate seeks write access to
+	// a user's private playlists.
+	PlaylistModifyPrivate = "playlist-modify-private"
+	// PlaylistReadCollaborative seeks permission to
+	// access a user's collaborative playlists.
+	PlaylistReadCollaborative = "playlist-read-collaborative"
+	// UserFollowModify seeks write/delete access to
+	// the list of artists and other users that a user follows.
+	UserFollowModify = "user-follow-modify"
+	// UserFollowRead seeks read access to the list of
+	// artists and other users that a user follows.
+	UserFollowRead = "user-follow-read"
+	// UserLibraryModify seeks write/delete access to a
+	// user's "Your Music" library.
+	UserLibraryModify = "user-library-modify"
+	// UserLibraryRead seeks read access to a user's "Your Music" library.
+	UserLibraryRead = "user-library-read"
+	// UserReadPrivate seeks read access to a user's
+	// subsription details (type of user account).
+	UserReadPrivate = "user-read-private"
+	// UserReadEmail seeks read access to a user's email address.
+	UserReadEmail = "user-read-email"
+	// UserReadCurrentlyPlaying seeks read access to a user's currently playing track
+	UserReadCurrentlyPlaying = "user-read-currently-playing"
+	// UserReadPlaybackState seeks read access to the user's current playback state
+	UserReadPlaybackState = "user-read-playback-state"
+	// UserModifyPlaybackState seeks write access to the user's current playback state
+	UserModifyPlaybackState = "user-modify-playback-state"
+	// UserReadRecentlyPlayed allows access to a user's recently-played songs
+	UserReadRecentlyPlayed = "user-read-recently-played"
+	// UserTopRead seeks read access to a user's top tracks and artists
+	UserTopRead = "user-top-read"
+	// UserReadPlaybackPosition seeks read access to a user's playback
+	// position in the episodes they listened to.
+	UserReadPlaybackPosition = "user-read-playback-position"
+	// Streaming seeks permission to play music and control playback on your other devices.
+	Streaming = "streaming"
+)
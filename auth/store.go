@@ -0,0 +1,207 @@
+package spotifyauth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrTokenNotFound is returned by TokenStore.Load when no token
+// is stored under the given key.
+var ErrTokenNotFound = errors.New("spotify: token not found")
+
+// TokenStore persists the tokens of several users, each identified by a key
+// such as their Spotify user ID.  Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Load returns the token stored under key, or ErrTokenNotFound.
+	Load(key string) (*oauth2.Token, error)
+	// Save stores token under key, replacing any previous token.
+	Save(key string, token *oauth2.Token) error
+}
+
+// FileStore is a TokenStore that keeps every token in its own file,
+// readable only by the current user, in a directory.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileStore returns a FileStore that keeps its files in dir,
+// creating the directory if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the name of the file holding the token stored under key.
+// Keys are encoded so that they can't escape the directory.
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(key))+".json")
+}
+
+// Load implements TokenStore.
+func (s *FileStore) Load(key string) (*oauth2.Token, error) {
+	b, err := s.read(key)
+	if err != nil {
+		return nil, err
+	}
+	var token oauth2.Token
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, fmt.Errorf("spotify: couldn't decode token: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore.  The token is written to a temporary file that
+// then replaces the previous one, so that a token is never partially written.
+func (s *FileStore) Save(key string, token *oauth2.Token) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return s.write(key, b)
+}
+
+// read returns the contents of the file stored under key.
+func (s *FileStore) read(key string) ([]byte, error) {
+	b, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrTokenNotFound
+	}
+	return b, err
+}
+
+// write replaces the contents of the file stored under key.
+func (s *FileStore) write(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(s.path(key), data)
+}
+
+// keys returns the keys of all the stored tokens.
+func (s *FileStore) keys() ([]string, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		key, err := base64.RawURLEncoding.DecodeString(strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		keys = append(keys, string(key))
+	}
+	return keys, nil
+}
+
+// writeFileAtomic replaces the contents of the named file with data.
+// The file is only readable and writable by the current user.
+func writeFileAtomic(name string, data []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(name), ".token-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// notifyingTokenSource calls notify whenever its source returns a new token.
+type notifyingTokenSource struct {
+	src    oauth2.TokenSource
+	notify func(*oauth2.Token) error
+
+	mu   sync.Mutex
+	last string
+}
+
+// NotifyingTokenSource returns a TokenSource that returns the tokens of src,
+// and calls notify every time src returns a token other than the previous one,
+// starting with initial.  If notify fails, its error is returned instead of
+// the token, and notify is called with the token again the next time.
+func NotifyingTokenSource(src oauth2.TokenSource, initial *oauth2.Token, notify func(*oauth2.Token) error) oauth2.TokenSource {
+	s := &notifyingTokenSource{src: src, notify: notify}
+	if initial != nil {
+		s.last = initial.AccessToken
+	}
+	return s
+}
+
+func (s *notifyingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.AccessToken != s.last {
+		if err := s.notify(token); err != nil {
+			return nil, fmt.Errorf("spotify: couldn't save refreshed token: %w", err)
+		}
+		s.last = token.AccessToken
+	}
+	return token, nil
+}
+
+// TokenSource returns a TokenSource that returns token until it expires,
+// and then refreshes it.
+func (a Authenticator) TokenSource(ctx context.Context, token *oauth2.Token) oauth2.TokenSource {
+	return a.config.TokenSource(contextWithHTTPClient(ctx), token)
+}
+
+// ClientFromStore is like Client, but it uses the token stored under key,
+// and saves every refreshed token back into store so that it survives
+// restarts.  It returns ErrTokenNotFound if store has no such token.
+func (a Authenticator) ClientFromStore(ctx context.Context, store TokenStore, key string) (*http.Client, error) {
+	token, err := store.Load(key)
+	if err != nil {
+		return nil, err
+	}
+	src := NotifyingTokenSource(a.TokenSource(ctx, token), token, func(t *oauth2.Token) error {
+		return store.Save(key, t)
+	})
+	return oauth2.NewClient(contextWithHTTPClient(ctx), src), nil
+}
+
+// ClientsFromStore returns a function that creates the client of the user
+// whose token is stored under their ID, as ClientFromStore does.  It can be
+// passed to spotify.NewClientPool.  ctx is used to refresh the tokens, and
+// should outlive the clients.
+func (a Authenticator) ClientsFromStore(ctx context.Context, store TokenStore) func(userID string) (*http.Client, error) {
+	return func(userID string) (*http.Client, error) {
+		return a.ClientFromStore(ctx, store, userID)
+	}
+}
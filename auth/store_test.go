@@ -0,0 +1,182 @@
+package spotifyauth_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/conradludgate/spotify/v2"
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+	"golang.org/x/oauth2"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tokens")
+	store, err := spotifyauth.NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Load("../alice"); !errors.Is(err, spotifyauth.ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	want := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", Expiry: expiry}
+	if err := store.Save("../alice", want); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("bob", &oauth2.Token{AccessToken: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.Load("../alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || !got.Expiry.Equal(expiry) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files in the store's directory, got %d", len(files))
+	}
+	if runtime.GOOS != "windows" {
+		for _, f := range files {
+			if perm := f.Mode().Perm(); perm != 0600 {
+				t.Errorf("Expected %s to have permissions 0600, got %o", f.Name(), perm)
+			}
+		}
+	}
+}
+
+func TestClientFromStore(t *testing.T) {
+	accounts := newAccounts(t, "client-secret")
+	accounts.SetTokenLifetime(5 * time.Second)
+	a := spotifyauth.New(
+		spotifyauth.WithClientID("client-id"),
+		spotifyauth.WithClientSecret("client-secret"),
+		spotifyauth.WithRedirectURL(redirectURL),
+		spotifyauth.WithEndpoint(accounts.Endpoint()),
+	)
+	ctx := context.Background()
+
+	store, err := spotifyauth.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.ClientFromStore(ctx, store, "alice"); !errors.Is(err, spotifyauth.ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+
+	token, err := a.Token(ctx, "state", authorize(t, a, "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("alice", token); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := a.ClientFromStore(ctx, store, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+	resp, err := client.Get(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	saved, err := store.Load("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken == token.AccessToken {
+		t.Error("Expected the refreshed token to be saved")
+	}
+	if _, ok := accounts.Valid(saved.AccessToken); !ok || saved.RefreshToken != token.RefreshToken {
+		t.Errorf("Unexpected saved token %+v", saved)
+	}
+}
+
+func TestClientsFromStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer alice-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "alice"}`))
+	}))
+	defer server.Close()
+
+	store, err := spotifyauth.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := &oauth2.Token{AccessToken: "alice-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)}
+	if err := store.Save("alice", token); err != nil {
+		t.Fatal(err)
+	}
+
+	clients := spotifyauth.New().ClientsFromStore(context.Background(), store)
+	pool := spotify.NewClientPool(clients, spotify.WithClientOptions(spotify.WithBaseURL(server.URL+"/")))
+	client, err := pool.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user, err := client.CurrentUser(context.Background()); err != nil || user.ID != "alice" {
+		t.Errorf("Expected alice's token to be used, got %v, %v", user, err)
+	}
+	if _, err := pool.Get("bob"); !errors.Is(err, spotifyauth.ErrTokenNotFound) {
+		t.Errorf("Expected ErrTokenNotFound, got %v", err)
+	}
+}
+
+func TestNotifyingTokenSourceRetries(t *testing.T) {
+	tokens := []*oauth2.Token{{AccessToken: "a"}, {AccessToken: "b"}, {AccessToken: "b"}, {AccessToken: "b"}}
+	i := 0
+	src := oauth2.TokenSource(tokenSourceFunc(func() (*oauth2.Token, error) {
+		t := tokens[i]
+		i++
+		return t, nil
+	}))
+
+	var notified []string
+	fail := true
+	src = spotifyauth.NotifyingTokenSource(src, tokens[0], func(t *oauth2.Token) error {
+		if fail {
+			fail = false
+			return os.ErrPermission
+		}
+		notified = append(notified, t.AccessToken)
+		return nil
+	})
+
+	// the first failure to save b is reported, and saving it is retried
+	for n, succeeds := range []bool{true, false, true, true} {
+		_, err := src.Token()
+		if (err == nil) != succeeds {
+			t.Errorf("call %d: unexpected error %v", n+1, err)
+		}
+	}
+	if len(notified) != 1 || notified[0] != "b" {
+		t.Errorf("Expected to be notified of b once, got %v", notified)
+	}
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) { return f() }
@@ -0,0 +1,314 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// This file contains variants of the calls that only accept a limited
+// number of IDs.  They split their IDs into chunks that each fit in a single
+// request, send the requests concurrently (see WithConcurrency) and stitch
+// the results back together in the order of the IDs.
+
+// ChunkError reports the failure of the request made for one chunk of IDs.
+type ChunkError struct {
+	// Start and End delimit the IDs of the chunk, as in ids[Start:End].
+	Start, End int
+	// Err is the error returned by the request.
+	Err error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("spotify: IDs %d to %d: %v", e.Start, e.End, e.Err)
+}
+
+// Unwrap returns the error returned by the request.
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+// ChunkErrors is returned by the calls that split their IDs into chunks when
+// some of the requests fail.  The results of the other chunks are still returned.
+type ChunkErrors []*ChunkError
+
+func (e ChunkErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%v (and %d more errors)", e[0], len(e)-1)
+}
+
+// Is reports whether the error of any chunk matches target.
+func (e ChunkErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first chunk whose error matches target, and if one is found,
+// sets target to that error value and returns true.  This gives access to the
+// Error or MissingScopeError that made a chunk fail.
+func (e ChunkErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// chunked calls fn concurrently with consecutive chunks of at most size IDs.
+func (c *Client) chunked(ctx context.Context, ids []ID, size int, fn func(ctx context.Context, start int, ids []ID) error) error {
+	bounds := func(i int) (int, int) {
+		start, end := i*size, (i+1)*size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		return start, end
+	}
+
+	n := (len(ids) + size - 1) / size
+	errs := c.parallel(ctx, n, func(ctx context.Context, i int) error {
+		start, end := bounds(i)
+		return fn(ctx, start, ids[start:end])
+	})
+
+	var chunkErrs ChunkErrors
+	for i, err := range errs {
+		if err != nil {
+			start, end := bounds(i)
+			chunkErrs = append(chunkErrs, &ChunkError{Start: start, End: end, Err: err})
+		}
+	}
+	if chunkErrs != nil {
+		return chunkErrs
+	}
+	return nil
+}
+
+// GetTracksAll is like GetTracks, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected tracks in the result are nil.
+func (c *Client) GetTracksAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullTrack, error) {
+	result := make([]*FullTrack, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		tracks, err := c.GetTracks(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], tracks)
+		return err
+	})
+	return result, err
+}
+
+// GetAlbumsAll is like GetAlbums, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected albums in the result are nil.
+func (c *Client) GetAlbumsAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullAlbum, error) {
+	result := make([]*FullAlbum, len(ids))
+	err := c.chunked(ctx, ids, 20, func(ctx context.Context, start int, ids []ID) error {
+		albums, err := c.GetAlbums(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], albums)
+		return err
+	})
+	return result, err
+}
+
+// GetArtistsAll is like GetArtists, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected artists in the result are nil.
+func (c *Client) GetArtistsAll(ctx context.Context, ids ...ID) ([]*FullArtist, error) {
+	result := make([]*FullArtist, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		artists, err := c.GetArtists(ctx, ids...)
+		copy(result[start:start+len(ids)], artists)
+		return err
+	})
+	return result, err
+}
+
+// GetAudioFeaturesAll is like GetAudioFeatures, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected tracks in the result are nil.
+func (c *Client) GetAudioFeaturesAll(ctx context.Context, ids ...ID) ([]*AudioFeatures, error) {
+	result := make([]*AudioFeatures, len(ids))
+	err := c.chunked(ctx, ids, 100, func(ctx context.Context, start int, ids []ID) error {
+		features, err := c.GetAudioFeatures(ctx, ids...)
+		copy(result[start:start+len(ids)], features)
+		return err
+	})
+	return result, err
+}
+
+// GetShowsAll is like GetShows, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected shows in the result are nil.
+func (c *Client) GetShowsAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*SimpleShow, error) {
+	result := make([]*SimpleShow, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		shows, err := c.GetShows(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], shows)
+		return err
+	})
+	return result, err
+}
+
+// GetEpisodesAll is like GetEpisodes, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected episodes in the result are nil.
+func (c *Client) GetEpisodesAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullEpisode, error) {
+	result := make([]*FullEpisode, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		episodes, err := c.GetEpisodes(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], episodes)
+		return err
+	})
+	return result, err
+}
+
+// GetAudiobooksAll is like GetAudiobooks, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected audiobooks in the result are nil.
+func (c *Client) GetAudiobooksAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*FullAudiobook, error) {
+	result := make([]*FullAudiobook, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		audiobooks, err := c.GetAudiobooks(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], audiobooks)
+		return err
+	})
+	return result, err
+}
+
+// GetChaptersAll is like GetChapters, but it accepts any number of IDs.
+// If some requests fail, the error is a ChunkErrors and the positions
+// of the affected chapters in the result are nil.
+func (c *Client) GetChaptersAll(ctx context.Context, ids []ID, opts ...RequestOption) ([]*Chapter, error) {
+	result := make([]*Chapter, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		chapters, err := c.GetChapters(ctx, ids, opts...)
+		copy(result[start:start+len(ids)], chapters)
+		return err
+	})
+	return result, err
+}
+
+// UserHasTracksAll is like UserHasTracks, but it accepts any number of IDs.
+func (c *Client) UserHasTracksAll(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContainsAll(ctx, "tracks", ids...)
+}
+
+// AddTracksToLibraryAll is like AddTracksToLibrary, but it accepts any number of IDs.
+func (c *Client) AddTracksToLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "tracks", true, ids...)
+}
+
+// RemoveTracksFromLibraryAll is like RemoveTracksFromLibrary, but it accepts any number of IDs.
+func (c *Client) RemoveTracksFromLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "tracks", false, ids...)
+}
+
+// UserHasAlbumsAll is like UserHasAlbums, but it accepts any number of IDs.
+func (c *Client) UserHasAlbumsAll(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContainsAll(ctx, "albums", ids...)
+}
+
+// AddAlbumsToLibraryAll is like AddAlbumsToLibrary, but it accepts any number of IDs.
+func (c *Client) AddAlbumsToLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "albums", true, ids...)
+}
+
+// RemoveAlbumsFromLibraryAll is like RemoveAlbumsFromLibrary, but it accepts any number of IDs.
+func (c *Client) RemoveAlbumsFromLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "albums", false, ids...)
+}
+
+// UserHasShowsAll is like UserHasShows, but it accepts any number of IDs.
+func (c *Client) UserHasShowsAll(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContainsAll(ctx, "shows", ids...)
+}
+
+// AddShowsToLibraryAll is like AddShowsToLibrary, but it accepts any number of IDs.
+func (c *Client) AddShowsToLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "shows", true, ids...)
+}
+
+// RemoveShowsFromLibraryAll is like RemoveShowsFromLibrary, but it accepts any number of IDs.
+func (c *Client) RemoveShowsFromLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "shows", false, ids...)
+}
+
+// UserHasEpisodesAll is like UserHasEpisodes, but it accepts any number of IDs.
+func (c *Client) UserHasEpisodesAll(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContainsAll(ctx, "episodes", ids...)
+}
+
+// AddEpisodesToLibraryAll is like AddEpisodesToLibrary, but it accepts any number of IDs.
+func (c *Client) AddEpisodesToLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "episodes", true, ids...)
+}
+
+// RemoveEpisodesFromLibraryAll is like RemoveEpisodesFromLibrary, but it accepts any number of IDs.
+func (c *Client) RemoveEpisodesFromLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "episodes", false, ids...)
+}
+
+// UserHasAudiobooksAll is like UserHasAudiobooks, but it accepts any number of IDs.
+func (c *Client) UserHasAudiobooksAll(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContainsAll(ctx, "audiobooks", ids...)
+}
+
+// AddAudiobooksToLibraryAll is like AddAudiobooksToLibrary, but it accepts any number of IDs.
+func (c *Client) AddAudiobooksToLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "audiobooks", true, ids...)
+}
+
+// RemoveAudiobooksFromLibraryAll is like RemoveAudiobooksFromLibrary, but it accepts any number of IDs.
+func (c *Client) RemoveAudiobooksFromLibraryAll(ctx context.Context, ids ...ID) error {
+	return c.modifyLibraryAll(ctx, "audiobooks", false, ids...)
+}
+
+func (c *Client) modifyLibraryAll(ctx context.Context, typ string, add bool, ids ...ID) error {
+	if len(ids) == 0 {
+		return errors.New("spotify: this call supports at least 1 ID")
+	}
+	return c.chunked(ctx, ids, 50, func(ctx context.Context, _ int, ids []ID) error {
+		return c.modifyLibrary(ctx, typ, add, ids...)
+	})
+}
+
+func (c *Client) libraryContainsAll(ctx context.Context, typ string, ids ...ID) ([]bool, error) {
+	if len(ids) == 0 {
+		return nil, errors.New("spotify: this call supports at least 1 ID")
+	}
+	result := make([]bool, len(ids))
+	err := c.chunked(ctx, ids, 50, func(ctx context.Context, start int, ids []ID) error {
+		contains, err := c.libraryContains(ctx, typ, ids...)
+		copy(result[start:start+len(ids)], contains)
+		return err
+	})
+	return result, err
+}
+
+// AddTracksToPlaylistAll is like AddTracksToPlaylist, but it accepts any number
+// of tracks.  Tracks are added 100 at a time, one request after the other, so
+// that they appear in the playlist in the order given.  It returns the snapshot
+// ID of the playlist after the last successful request.
+//
+// If a request fails, the tracks that follow are not added and the error is
+// a ChunkErrors describing the failed chunk.
+func (c *Client) AddTracksToPlaylistAll(ctx context.Context, playlistID ID, trackIDs ...ID) (snapshotID string, err error) {
+	for start := 0; start < len(trackIDs); start += 100 {
+		end := start + 100
+		if end > len(trackIDs) {
+			end = len(trackIDs)
+		}
+		id, err := c.AddTracksToPlaylist(ctx, playlistID, trackIDs[start:end]...)
+		if err != nil {
+			return snapshotID, ChunkErrors{{Start: start, End: end, Err: err}}
+		}
+		snapshotID = id
+	}
+	return snapshotID, nil
+}
@@ -0,0 +1,160 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func numberedIDs(n int) []ID {
+	ids := make([]ID, n)
+	for i := range ids {
+		ids[i] = ID(strconv.Itoa(i))
+	}
+	return ids
+}
+
+func TestGetTracksAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		if len(ids) > 50 {
+			t.Errorf("Too many IDs in a single request: %d", len(ids))
+		}
+		if ids[0] == "100" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		tracks := make([]string, len(ids))
+		for i, id := range ids {
+			tracks[i] = fmt.Sprintf(`{"id": "%s"}`, id)
+		}
+		fmt.Fprintf(w, `{"tracks": [%s]}`, strings.Join(tracks, ","))
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL + "/"))
+
+	tracks, err := client.GetTracksAll(context.Background(), numberedIDs(120))
+
+	var chunkErrs ChunkErrors
+	if !errors.As(err, &chunkErrs) {
+		t.Fatalf("Expected ChunkErrors, got %v", err)
+	}
+	if len(chunkErrs) != 1 || chunkErrs[0].Start != 100 || chunkErrs[0].End != 120 {
+		t.Errorf("Unexpected chunk errors: %v", chunkErrs)
+	}
+	var serr Error
+	if !errors.As(chunkErrs[0], &serr) || serr.Status != http.StatusBadGateway {
+		t.Errorf("Expected the chunk to fail with HTTP 502, got %v", chunkErrs[0].Err)
+	}
+	serr = Error{}
+	if !errors.As(err, &serr) || serr.Status != http.StatusBadGateway {
+		t.Errorf("Expected errors.As to find the chunk's Error, got %v", err)
+	}
+
+	if len(tracks) != 120 {
+		t.Fatalf("Expected 120 results, got %d", len(tracks))
+	}
+	for i, track := range tracks {
+		switch {
+		case i >= 100 && track != nil:
+			t.Errorf("Expected track %d to be nil", i)
+		case i < 100 && (track == nil || track.ID != ID(strconv.Itoa(i))):
+			t.Errorf("Unexpected track at position %d: %v", i, track)
+		}
+	}
+}
+
+func TestLibraryAll(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		saved = map[string]bool{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids := strings.Split(r.URL.Query().Get("ids"), ",")
+		if len(ids) > 50 {
+			t.Errorf("Too many IDs in a single request: %d", len(ids))
+		}
+		if r.Method == http.MethodPut {
+			for _, id := range ids {
+				saved[id] = true
+			}
+			return
+		}
+		contains := make([]string, len(ids))
+		for i, id := range ids {
+			contains[i] = strconv.FormatBool(saved[id])
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(contains, ","))
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL + "/"))
+
+	ids := numberedIDs(130)
+	if err := client.AddTracksToLibraryAll(context.Background(), ids[:75]...); err != nil {
+		t.Fatal(err)
+	}
+	contains, err := client.UserHasTracksAll(context.Background(), ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range contains {
+		if ok != (i < 75) {
+			t.Errorf("Unexpected result for track %d: %t", i, ok)
+		}
+	}
+}
+
+func TestLibraryAllMissingScope(t *testing.T) {
+	client := New(WithGrantedScopes())
+
+	err := client.AddTracksToLibraryAll(context.Background(), numberedIDs(60)...)
+	var mse *MissingScopeError
+	if !errors.As(err, &mse) || len(mse.Missing) != 1 {
+		t.Fatalf("Expected a MissingScopeError, got %v", err)
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected the error to match ErrForbidden, got %v", err)
+	}
+}
+
+func TestAddTracksToPlaylistAll(t *testing.T) {
+	var added []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			URIs []string `json:"uris"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.URIs) > 100 {
+			t.Errorf("Too many tracks in a single request: %d", len(body.URIs))
+		}
+		added = append(added, body.URIs...)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"snapshot_id": "snapshot-%d"}`, len(added))
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL + "/"))
+
+	snapshot, err := client.AddTracksToPlaylistAll(context.Background(), "playlist", numberedIDs(250)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot != "snapshot-250" {
+		t.Errorf("Expected the last snapshot ID, got %s", snapshot)
+	}
+	for i, uri := range added {
+		if uri != "spotify:track:"+strconv.Itoa(i) {
+			t.Fatalf("Track %d was added out of order: %s", i, uri)
+		}
+	}
+}
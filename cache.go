@@ -0,0 +1,169 @@
+package spotify
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is the body of a successful response to a GET request,
+// as stored in a Cache.
+type CachedResponse struct {
+	// Body is the raw body of the response.
+	Body []byte
+	// ETag is the entity tag of the response, used to revalidate it
+	// once it has expired.  It may be empty.
+	ETag string
+	// Expires is the time until which the response can be used
+	// without asking the server whether it is still valid.
+	Expires time.Time
+}
+
+// Cache stores responses to GET requests, keyed by the request's URL and
+// Accept-Language header.  Stored responses must be treated as read-only.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+	Delete(key string)
+}
+
+// WithCache configures the Spotify API client to cache the responses to GET
+// requests.  Responses are reused for as long as their Cache-Control max-age
+// allows, then revalidated with their ETag.  Responses marked no-store are
+// never cached.
+//
+// Since responses can depend on the access token, a cache should not be
+// shared by clients acting on behalf of different users.
+func WithCache(cache Cache) ClientOption {
+	return func(client *Client) {
+		client.cache = cache
+	}
+}
+
+// cacheKey returns the key under which the response to a GET of url is cached.
+func (c *Client) cacheKey(url string) string {
+	return url + "\n" + c.acceptLanguage
+}
+
+// cacheEntry returns the entry to store for a response with the given body,
+// or nil if the response shouldn't be cached.
+func cacheEntry(resp *http.Response, body []byte) *CachedResponse {
+	maxAge, ok := parseMaxAge(resp.Header.Get("Cache-Control"))
+	if !ok {
+		return nil
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" && maxAge <= 0 {
+		return nil
+	}
+	return &CachedResponse{
+		Body:    body,
+		ETag:    etag,
+		Expires: time.Now().Add(maxAge),
+	}
+}
+
+// parseMaxAge returns how long a response with the given Cache-Control header
+// may be reused for, and false if it must not be stored at all.
+func parseMaxAge(cacheControl string) (time.Duration, bool) {
+	var maxAge time.Duration
+	noCache := false
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store":
+			return 0, false
+		case directive == "no-cache":
+			noCache = true
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	if noCache {
+		return 0, true
+	}
+	return maxAge, true
+}
+
+// LRUCache is an in-memory Cache that holds a limited number of responses,
+// evicting the least recently used one when it is full.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUCache returns an LRUCache that holds up to size responses.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+// Get returns the response stored under key, if any.
+func (l *LRUCache) Get(key string) (*CachedResponse, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(e)
+	return e.Value.(*lruEntry).resp, true
+}
+
+// Set stores resp under key, evicting the least recently used
+// response if the cache is full.
+func (l *LRUCache) Set(key string, resp *CachedResponse) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.entries[key]; ok {
+		e.Value.(*lruEntry).resp = resp
+		l.order.MoveToFront(e)
+		return
+	}
+	if l.size <= 0 {
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, resp: resp})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the response stored under key, if any.
+func (l *LRUCache) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.entries[key]; ok {
+		l.order.Remove(e)
+		delete(l.entries, key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
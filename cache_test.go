@@ -0,0 +1,164 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheMaxAge(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "public, max-age=7200")
+		http.ServeFile(w, r, "test_data/find_artist.txt")
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL+"/"), WithCache(NewLRUCache(10)))
+
+	for i := 0; i < 3; i++ {
+		artist, err := client.GetArtist(context.Background(), "0TnOYISbd1XYRBk9myaseg")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if artist.Name != "Pitbull" {
+			t.Errorf("Unexpected artist name: %s", artist.Name)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got %d", requests)
+	}
+
+	// Responses are cached per language.
+	WithAcceptLanguage("es")(client)
+	if _, err := client.GetArtist(context.Background(), "0TnOYISbd1XYRBk9myaseg"); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	var revalidated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "private, max-age=0")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeFile(w, r, "test_data/find_track.txt")
+	}))
+	defer server.Close()
+	cache := NewLRUCache(10)
+	client := New(WithBaseURL(server.URL+"/"), WithCache(cache))
+
+	for i := 0; i < 3; i++ {
+		track, err := client.GetTrack(context.Background(), "1zHlj4dQ8ZAtrayhuDDmkY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Name != "Timber" {
+			t.Errorf("Unexpected track name: %s", track.Name)
+		}
+	}
+	if revalidated != 2 {
+		t.Errorf("Expected 2 revalidations, got %d", revalidated)
+	}
+	if resp, ok := cache.Get(client.cacheKey(server.URL + "/tracks/1zHlj4dQ8ZAtrayhuDDmkY")); !ok || resp.ETag != `"v1"` {
+		t.Error("Expected the ETag to be kept after revalidation")
+	}
+}
+
+func TestCacheNoStore(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeFile(w, r, "test_data/find_track.txt")
+	}))
+	defer server.Close()
+	cache := NewLRUCache(10)
+	client := New(WithBaseURL(server.URL+"/"), WithCache(cache))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetTrack(context.Background(), "1zHlj4dQ8ZAtrayhuDDmkY"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests != 2 || cache.Len() != 0 {
+		t.Errorf("Expected nothing to be cached, got %d requests and %d entries", requests, cache.Len())
+	}
+}
+
+func TestCacheNoCacheNoStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeFile(w, r, "test_data/find_track.txt")
+	}))
+	defer server.Close()
+	cache := NewLRUCache(10)
+	client := New(WithBaseURL(server.URL+"/"), WithCache(cache))
+
+	if _, err := client.GetTrack(context.Background(), "1zHlj4dQ8ZAtrayhuDDmkY"); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected nothing to be cached, got %d entries", cache.Len())
+	}
+}
+
+func TestCacheNotModifiedNoStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.Header().Set("Cache-Control", "no-cache, no-store")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Cache-Control", "private, max-age=0")
+		w.Header().Set("ETag", `"v1"`)
+		http.ServeFile(w, r, "test_data/find_track.txt")
+	}))
+	defer server.Close()
+	cache := NewLRUCache(10)
+	client := New(WithBaseURL(server.URL+"/"), WithCache(cache))
+
+	for i := 0; i < 2; i++ {
+		track, err := client.GetTrack(context.Background(), "1zHlj4dQ8ZAtrayhuDDmkY")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if track.Name != "Timber" {
+			t.Errorf("Unexpected track name: %s", track.Name)
+		}
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected the entry to be evicted, got %d entries", cache.Len())
+	}
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", &CachedResponse{Expires: time.Now()})
+	cache.Set("b", &CachedResponse{Expires: time.Now()})
+	cache.Get("a")
+	cache.Set("c", &CachedResponse{Expires: time.Now()})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected the least recently used entry to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
@@ -45,18 +45,25 @@ func (c *Client) GetCategoryPlaylists(ctx context.Context, catID string, opts ..
 		spotifyURL += "?" + params
 	}
 
-	wrapper := struct {
-		Playlists SimplePlaylistPage `json:"playlists"`
-	}{}
+	wrapper := categoryPlaylists{}
 
 	err := c.get(ctx, spotifyURL, &wrapper)
 	if err != nil {
 		return nil, err
 	}
+	wrapper.Playlists.newWrapper = func() wrappedPage { return new(categoryPlaylists) }
 
 	return &wrapper.Playlists, nil
 }
 
+// categoryPlaylists is the object that the playlists of a category are
+// wrapped in.
+type categoryPlaylists struct {
+	Playlists SimplePlaylistPage `json:"playlists"`
+}
+
+func (c *categoryPlaylists) unwrapPage() pageable { return &c.Playlists }
+
 // GetCategories gets a list of categories used to tag items in Spotify
 //
 // Supported options: Country, Locale, Limit, Offset
@@ -66,14 +73,20 @@ func (c *Client) GetCategories(ctx context.Context, opts ...RequestOption) (*Cat
 		spotifyURL += "?" + query
 	}
 
-	wrapper := struct {
-		Categories CategoryPage `json:"categories"`
-	}{}
+	wrapper := categories{}
 
 	err := c.get(ctx, spotifyURL, &wrapper)
 	if err != nil {
 		return nil, err
 	}
+	wrapper.Categories.newWrapper = func() wrappedPage { return new(categories) }
 
 	return &wrapper.Categories, nil
 }
+
+// categories is the object that the list of categories is wrapped in.
+type categories struct {
+	Categories CategoryPage `json:"categories"`
+}
+
+func (c *categories) unwrapPage() pageable { return &c.Categories }
@@ -0,0 +1,55 @@
+package spotify
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultConcurrency is the number of requests sent at once by calls that
+// split their work across several requests, unless WithConcurrency is used.
+const defaultConcurrency = 4
+
+// WithConcurrency configures how many requests the client may send at once when
+// a single call, such as FetchAll, needs several requests to complete.
+func WithConcurrency(n int) ClientOption {
+	return func(client *Client) {
+		client.concurrency = n
+	}
+}
+
+// parallel calls fn for every i in [0, n), running at most as many calls at
+// once as the client's concurrency allows.  It returns the error of each call.
+// Calls that haven't started when ctx is done are skipped and report ctx.Err().
+func (c *Client) parallel(ctx context.Context, n int, fn func(ctx context.Context, i int) error) []error {
+	workers := c.concurrency
+	if workers <= 0 {
+		workers = defaultConcurrency
+	}
+	if workers > n {
+		workers = n
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					errs[i] = err
+					continue
+				}
+				errs[i] = fn(ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errs
+}
@@ -9,6 +9,9 @@ package spotify
 // of items.
 type Cursor struct {
 	After string `json:"after"`
+	// Before is only set by endpoints that can be read in both
+	// directions, such as the recently played tracks.
+	Before string `json:"before"`
 }
 
 // cursorPage contains all of the fields in a Spotify cursor-based
@@ -27,8 +30,12 @@ type cursorPage struct {
 	Total int `json:"total"`
 	// The cursor used to find the next set of items.
 	Cursor Cursor `json:"cursors"`
+
+	wrapping
 }
 
+func (c *cursorPage) canPage() {}
+
 // FullArtistCursorPage is a cursor-based paging object containing
 // a set of FullArtist objects.
 type FullArtistCursorPage struct {
@@ -0,0 +1,120 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const episodeJSON = `{
+  "audio_preview_url": "https://p.scdn.co/mp3-preview/7a785904a33e34b0b2bd382c82fca16be7060c36",
+  "description": "Följ med Leif GW Persson och Dan Josefsson.",
+  "duration_ms": 2685023,
+  "explicit": false,
+  "external_urls": {"spotify": "https://open.spotify.com/episode/512ojhOuo1ktJprKbVcKyQ"},
+  "href": "https://api.spotify.com/v1/episodes/512ojhOuo1ktJprKbVcKyQ",
+  "id": "512ojhOuo1ktJprKbVcKyQ",
+  "images": [{"height": 640, "url": "https://i.scdn.co/image/de4a5f115ac6f6ca4cae4fb7aaf27bacad7b3ed5", "width": 640}],
+  "is_externally_hosted": false,
+  "is_playable": true,
+  "languages": ["sv"],
+  "name": "Tredje rikets knarkande granskas",
+  "release_date": "2015-10-01",
+  "release_date_precision": "day",
+  "resume_point": {"fully_played": false, "resume_position_ms": 518000},
+  "show": {
+    "id": "38bS44xjbVVZ3No3ByF1dJ",
+    "name": "Vetenskapsradion Historia",
+    "publisher": "Sveriges Radio",
+    "type": "show",
+    "uri": "spotify:show:38bS44xjbVVZ3No3ByF1dJ"
+  },
+  "type": "episode",
+  "uri": "spotify:episode:512ojhOuo1ktJprKbVcKyQ"
+}`
+
+func TestGetEpisode(t *testing.T) {
+	client, server := testClientString(http.StatusOK, episodeJSON, func(req *http.Request) {
+		if req.URL.Path != "/episodes/512ojhOuo1ktJprKbVcKyQ" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+		if m := req.URL.Query().Get("market"); m != "SE" {
+			t.Errorf("Expected market SE, got %q", m)
+		}
+	})
+	defer server.Close()
+
+	e, err := client.GetEpisode(context.Background(), "512ojhOuo1ktJprKbVcKyQ", Market("SE"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "Tredje rikets knarkande granskas" {
+		t.Error("Invalid data:", e.Name)
+	}
+	if e.Show.Name != "Vetenskapsradion Historia" {
+		t.Error("Invalid show:", e.Show.Name)
+	}
+	if e.ResumePoint.ResumePositionMs != 518000 {
+		t.Error("Invalid resume point:", e.ResumePoint.ResumePositionMs)
+	}
+	if d := e.ReleaseDateTime(); !d.Equal(time.Date(2015, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Invalid release date:", d)
+	}
+}
+
+func TestGetEpisodes(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"episodes": [`+episodeJSON+`, null]}`, func(req *http.Request) {
+		if ids := req.URL.Query().Get("ids"); ids != "512ojhOuo1ktJprKbVcKyQ,0000000000000000000000" {
+			t.Errorf("Unexpected ids %q", ids)
+		}
+	})
+	defer server.Close()
+
+	episodes, err := client.GetEpisodes(context.Background(), []ID{"512ojhOuo1ktJprKbVcKyQ", "0000000000000000000000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(episodes) != 2 {
+		t.Fatal("Expected 2 episodes, got", len(episodes))
+	}
+	if episodes[0] == nil || episodes[0].ID != "512ojhOuo1ktJprKbVcKyQ" {
+		t.Error("Invalid first episode")
+	}
+	if episodes[1] != nil {
+		t.Error("Expected the missing episode to be nil")
+	}
+
+	if _, err := client.GetEpisodes(context.Background(), make([]ID, 51)); err == nil {
+		t.Error("Expected an error for more than 50 IDs")
+	}
+}
+
+func TestCurrentUsersEpisodes(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{
+  "href": "https://api.spotify.com/v1/me/episodes?offset=0&limit=20",
+  "items": [{"added_at": "2021-09-01T10:00:00Z", "episode": `+episodeJSON+`}],
+  "limit": 20,
+  "next": null,
+  "offset": 0,
+  "previous": null,
+  "total": 1
+}`, func(req *http.Request) {
+		if req.URL.Path != "/me/episodes" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	page, err := client.CurrentUsersEpisodes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 || len(page.Episodes) != 1 {
+		t.Fatal("Expected 1 episode, got", len(page.Episodes))
+	}
+	e := page.Episodes[0]
+	if e.AddedAt != "2021-09-01T10:00:00Z" || e.ID != "512ojhOuo1ktJprKbVcKyQ" || e.Show.ID != "38bS44xjbVVZ3No3ByF1dJ" {
+		t.Errorf("Invalid data: %+v", e)
+	}
+}
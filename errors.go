@@ -0,0 +1,78 @@
+package spotify
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors that an Error returned by the Spotify Web API can be
+// matched against with errors.Is.  For example:
+//
+//	err := client.Play(ctx)
+//	if errors.Is(err, spotify.ErrNoActiveDevice) {
+//		// ask the user to open Spotify on one of their devices
+//	}
+//
+// To inspect the details of the error, such as how long to wait before
+// retrying a rate limited request, use errors.As with a spotify.Error.
+var (
+	// ErrUnauthorized matches errors caused by a missing, invalid or expired access token.
+	ErrUnauthorized = errors.New("spotify: unauthorized")
+	// ErrForbidden matches errors caused by a request the access token doesn't allow,
+	// usually because a scope is missing.
+	ErrForbidden = errors.New("spotify: forbidden")
+	// ErrNotFound matches errors caused by a resource that doesn't exist.
+	ErrNotFound = errors.New("spotify: not found")
+	// ErrRateLimited matches errors caused by sending requests too quickly.
+	// The RetryAfter field of the Error says how long to wait before trying again.
+	ErrRateLimited = errors.New("spotify: rate limited")
+	// ErrNoActiveDevice matches player errors caused by the user not having
+	// any device to control.
+	ErrNoActiveDevice = errors.New("spotify: no active device")
+	// ErrPremiumRequired matches player errors caused by the user not having
+	// a Spotify Premium subscription.
+	ErrPremiumRequired = errors.New("spotify: premium required")
+)
+
+// Reasons given by the player endpoints for rejecting a command.
+// See the Reason field of Error.
+const (
+	ReasonNoPreviousTrack       = "NO_PREV_TRACK"
+	ReasonNoNextTrack           = "NO_NEXT_TRACK"
+	ReasonNoSpecificTrack       = "NO_SPECIFIC_TRACK"
+	ReasonAlreadyPaused         = "ALREADY_PAUSED"
+	ReasonNotPaused             = "NOT_PAUSED"
+	ReasonNotPlayingLocally     = "NOT_PLAYING_LOCALLY"
+	ReasonNotPlayingTrack       = "NOT_PLAYING_TRACK"
+	ReasonNotPlayingContext     = "NOT_PLAYING_CONTEXT"
+	ReasonEndlessContext        = "ENDLESS_CONTEXT"
+	ReasonContextDisallow       = "CONTEXT_DISALLOW"
+	ReasonAlreadyPlaying        = "ALREADY_PLAYING"
+	ReasonRateLimited           = "RATE_LIMITED"
+	ReasonRemoteControlDisallow = "REMOTE_CONTROL_DISALLOW"
+	ReasonDeviceNotControllable = "DEVICE_NOT_CONTROLLABLE"
+	ReasonVolumeControlDisallow = "VOLUME_CONTROL_DISALLOW"
+	ReasonNoActiveDevice        = "NO_ACTIVE_DEVICE"
+	ReasonPremiumRequired       = "PREMIUM_REQUIRED"
+	ReasonUnknown               = "UNKNOWN"
+)
+
+// Is reports whether e matches target, which should be one of the
+// sentinel errors of this package.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Status == rateLimitExceededStatusCode || e.Reason == ReasonRateLimited
+	case ErrNoActiveDevice:
+		return e.Reason == ReasonNoActiveDevice
+	case ErrPremiumRequired:
+		return e.Reason == ReasonPremiumRequired
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorNoActiveDevice(t *testing.T) {
+	body := `{
+		"error": {
+			"status": 404,
+			"message": "Player command failed: No active device found",
+			"reason": "NO_ACTIVE_DEVICE"
+		}
+	}`
+	client, server := testClientString(http.StatusNotFound, body)
+	defer server.Close()
+
+	err := client.Play(context.Background())
+	if !errors.Is(err, ErrNoActiveDevice) {
+		t.Errorf("Expected ErrNoActiveDevice, got %v", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrPremiumRequired) {
+		t.Error("Didn't expect ErrPremiumRequired")
+	}
+
+	var serr Error
+	if !errors.As(err, &serr) {
+		t.Fatal("Expected a spotify.Error")
+	}
+	if serr.Reason != ReasonNoActiveDevice {
+		t.Errorf("Expected reason %s, got %s", ReasonNoActiveDevice, serr.Reason)
+	}
+	if serr.URL != server.URL+"/me/player/play" {
+		t.Errorf("Unexpected URL %s", serr.URL)
+	}
+	if serr.Body != body {
+		t.Error("Expected the raw body to be preserved")
+	}
+}
+
+func TestErrorRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL + "/"))
+	_, err := client.GetTrack(context.Background(), "1zHlj4dQ8ZAtrayhuDDmkY")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	var serr Error
+	if !errors.As(err, &serr) || serr.RetryAfter != 7*time.Second {
+		t.Errorf("Expected to retry after 7s, got %v", serr.RetryAfter)
+	}
+	if !strings.Contains(err.Error(), "body empty") {
+		t.Errorf("Unexpected message: %s", err)
+	}
+}
+
+func TestErrorUnauthorized(t *testing.T) {
+	client, server := testClientString(http.StatusUnauthorized, `not json`)
+	defer server.Close()
+
+	_, err := client.CurrentUser(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized, got %v", err)
+	}
+	if errors.Is(err, ErrForbidden) {
+		t.Error("Didn't expect ErrForbidden")
+	}
+}
@@ -0,0 +1,42 @@
+// This example demonstrates how command line tools can authenticate with
+// Spotify without running a web server of their own.
+// In order to run this example yourself, you'll need to:
+//
+//  1. Register an application at: https://developer.spotify.com/my-applications/
+//     - Use "http://127.0.0.1:8080/callback" as the redirect URI
+//  2. Set the SPOTIFY_ID environment variable to the client ID you got in step 1.
+//  3. Optionally, set the SPOTIFY_SECRET environment variable to the client secret
+//     from step 1.  Without it, PKCE is used instead.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/conradludgate/spotify/v2"
+	spotifyauth "github.com/conradludgate/spotify/v2/auth"
+)
+
+// redirectURI is the OAuth redirect URI for the application.
+// You must register an application at Spotify's developer portal
+// and enter this value.
+const redirectURI = "http://127.0.0.1:8080/callback"
+
+func main() {
+	ctx := context.Background()
+	auth := spotifyauth.New(spotifyauth.WithRedirectURL(redirectURI), spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate))
+
+	// LoginLocal prints the URL to visit, and waits for the redirect
+	token, err := auth.LoginLocal(ctx, spotifyauth.LocalLoginOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := spotify.New(spotify.WithHTTPClient(auth.Client(ctx, token)))
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("You are logged in as:", user.ID)
+}
@@ -13,8 +13,6 @@ import (
 	"log"
 	"net/http"
 
-	"golang.org/x/oauth2"
-
 	"github.com/conradludgate/spotify/v2"
 )
 
@@ -24,14 +22,12 @@ import (
 const redirectURI = "http://localhost:8080/callback"
 
 var (
-	auth  = spotifyauth.New(spotifyauth.WithRedirectURL(redirectURI), spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate))
+	// no client secret is needed with PKCE
+	auth  = spotifyauth.New(spotifyauth.WithRedirectURL(redirectURI), spotifyauth.WithClientSecret(""), spotifyauth.WithScopes(spotifyauth.ScopeUserReadPrivate))
 	ch    = make(chan *spotify.Client)
 	state = "abc123"
-	// These should be randomly generated for each request
-	//  More information on generating these can be found here,
-	// https://www.oauth.com/playground/authorization-code-with-pkce.html
-	codeVerifier  = "w0HfYrKnG8AihqYHA9_XUPTIcqEXQvCQfOF2IitRgmlF43YWJ8dy2b49ZUwVUOR.YnvzVoTBL57BwIhM4ouSa~tdf0eE_OmiMC_ESCcVOe7maSLIk9IOdBhRstAxjCl7"
-	codeChallenge = "ZhZJzPQXYBMjH8FlGAdYK5AndohLzFfZT-8J7biT7ig"
+	// codeVerifier is generated for each authorization request
+	codeVerifier string
 )
 
 func main() {
@@ -42,10 +38,12 @@ func main() {
 	})
 	go http.ListenAndServe(":8080", nil)
 
-	url := auth.AuthURL(state,
-		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
-		oauth2.SetAuthURLParam("code_challenge", codeChallenge),
-	)
+	var err error
+	codeVerifier, err = spotifyauth.GenerateVerifier()
+	if err != nil {
+		log.Fatal(err)
+	}
+	url := auth.AuthURLWithPKCE(state, codeVerifier)
 	fmt.Println("Please log in to Spotify by visiting the following page in your browser:", url)
 
 	// wait for auth to complete
@@ -60,8 +58,7 @@ func main() {
 }
 
 func completeAuth(w http.ResponseWriter, r *http.Request) {
-	tok, err := auth.Token(r.Context(), state, r,
-		oauth2.SetAuthURLParam("code_verifier", codeVerifier))
+	tok, err := auth.Token(r.Context(), state, r, spotifyauth.VerifierOption(codeVerifier))
 	if err != nil {
 		http.Error(w, "Couldn't get token", http.StatusForbidden)
 		log.Fatal(err)
@@ -4,24 +4,12 @@ import (
 	"context"
 	"github.com/conradludgate/spotify/v2"
 	spotifyauth "github.com/conradludgate/spotify/v2/auth"
-	"golang.org/x/oauth2/clientcredentials"
 	"log"
-	"os"
 )
 
 func main() {
 	ctx := context.Background()
-	config := &clientcredentials.Config{
-		ClientID:     os.Getenv("SPOTIFY_ID"),
-		ClientSecret: os.Getenv("SPOTIFY_SECRET"),
-		TokenURL:     spotifyauth.TokenURL,
-	}
-	token, err := config.Token(ctx)
-	if err != nil {
-		log.Fatalf("couldn't get token: %v", err)
-	}
-
-	httpClient := spotifyauth.New().Client(ctx, token)
+	httpClient := spotifyauth.New().ClientCredentialsClient(ctx)
 	client := spotify.New(spotify.WithHTTPClient(httpClient))
 
 	tracks, err := client.GetPlaylistTracks(ctx, "57qttz6pK881sjxj2TAEEo")
@@ -4,5 +4,6 @@ go 1.16
 
 require (
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
 )
@@ -0,0 +1,211 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	// Register the formats of the images served by Spotify, so that
+	// DownloadImage can find out their dimensions.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ImageInfo describes an image downloaded by DownloadImage.
+type ImageInfo struct {
+	// ContentType is the MIME type of the image, such as "image/jpeg".
+	ContentType string
+	// Width and Height are the dimensions of the image, in pixels.
+	// They are those given by the Image if the format isn't known.
+	Width  int
+	Height int
+	// Size is the number of bytes written.
+	Size int64
+	// Cached is true if the image was read from the client's ImageCache.
+	Cached bool
+}
+
+// ImageCache keeps downloaded images on disk, in a directory, so that they are
+// only downloaded once.  Images are keyed by their URL, which Spotify changes
+// whenever the image changes.  ImageCache is safe for concurrent use.
+type ImageCache struct {
+	dir string
+}
+
+// NewImageCache returns an ImageCache that keeps its files in dir,
+// creating the directory if needed.
+func NewImageCache(dir string) (*ImageCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &ImageCache{dir: dir}, nil
+}
+
+// path returns the name of the file holding the image downloaded from url.
+func (ic *ImageCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(ic.dir, hex.EncodeToString(sum[:]))
+}
+
+// get returns the data of the image downloaded from url, if it is cached.
+func (ic *ImageCache) get(url string) ([]byte, bool) {
+	data, err := ioutil.ReadFile(ic.path(url))
+	return data, err == nil
+}
+
+// set stores the data of the image downloaded from url.  The data is written
+// to a temporary file first, so that a partially written image is never read.
+func (ic *ImageCache) set(url string, data []byte) error {
+	f, err := ioutil.TempFile(ic.dir, ".image-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), ic.path(url))
+}
+
+// WithImageCache configures the client to keep the images downloaded
+// with DownloadImage in cache.
+func WithImageCache(cache *ImageCache) ClientOption {
+	return func(client *Client) {
+		client.imageCache = cache
+	}
+}
+
+// defaultImageHTTPClient is the http client DownloadImage uses unless
+// WithImageHTTPClient is used.  Unlike http.DefaultClient, it gives up on
+// downloads that hang.
+var defaultImageHTTPClient = &http.Client{Timeout: time.Minute}
+
+// WithImageHTTPClient sets the http client DownloadImage uses to fetch
+// images.  By default, downloads time out after a minute.
+func WithImageHTTPClient(c *http.Client) ClientOption {
+	return func(client *Client) {
+		client.imageHTTP = c
+	}
+}
+
+// DownloadImage downloads img and writes its data to dst.  Unlike
+// Image.Download, the request can be cancelled with ctx.  The image is read
+// from the client's ImageCache instead, if it has one that holds the image.
+//
+// Images are served by Spotify's CDN, not by the Web API, so they are fetched
+// with the client set by WithImageHTTPClient rather than the authenticated
+// one: the user's access token is never sent with the request, and downloads
+// don't count against the client's rate limit.  They are retried according
+// to the client's retry policy, like reads from the Web API.
+func (c *Client) DownloadImage(ctx context.Context, img Image, dst io.Writer) (*ImageInfo, error) {
+	var data []byte
+	var contentType string
+	cached := false
+	if c.imageCache != nil {
+		data, cached = c.imageCache.get(img.URL)
+	}
+	if !cached {
+		var err error
+		data, contentType, err = c.fetchImage(ctx, img.URL)
+		if err != nil {
+			return nil, err
+		}
+		if c.imageCache != nil {
+			// The image was downloaded all the same, so failing to
+			// cache it, on a full disk say, only costs a download later.
+			_ = c.imageCache.set(img.URL, data)
+		}
+	}
+
+	info := &ImageInfo{ContentType: contentType, Width: img.Width, Height: img.Height, Cached: cached}
+	if info.ContentType == "" {
+		info.ContentType = http.DetectContentType(data)
+	}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		info.Width, info.Height = cfg.Width, cfg.Height
+	}
+	n, err := dst.Write(data)
+	info.Size = int64(n)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// fetchImage downloads the image at url, and returns its data
+// and the content type given by the server, if any.
+func (c *Client) fetchImage(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	client := c.imageHTTP
+	if client == nil {
+		client = defaultImageHTTPClient
+	}
+	resp, err := c.doWith(client, nil, req, true)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", c.decodeError(resp)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if contentType == "application/octet-stream" {
+		contentType = ""
+	}
+	return data, contentType, nil
+}
+
+// BestImage returns the image that best fits a width x height area: the
+// smallest image covering the area, or the largest image if none does.
+// Images of unknown size are only returned if all images are.  It returns
+// false if images is empty.
+func BestImage(images []Image, width, height int) (Image, bool) {
+	if len(images) == 0 {
+		return Image{}, false
+	}
+	best := -1
+	for i, img := range images {
+		if img.Width <= 0 || img.Height <= 0 {
+			continue
+		}
+		if best < 0 {
+			best = i
+			continue
+		}
+		b := images[best]
+		covers, bestCovers := img.Width >= width && img.Height >= height, b.Width >= width && b.Height >= height
+		switch {
+		case covers && !bestCovers:
+			best = i
+		case covers && bestCovers && img.Width*img.Height < b.Width*b.Height:
+			best = i
+		case !covers && !bestCovers && img.Width*img.Height > b.Width*b.Height:
+			best = i
+		}
+	}
+	if best < 0 {
+		return images[0], true
+	}
+	return images[best], true
+}
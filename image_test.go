@@ -0,0 +1,189 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func pngImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDownloadImage(t *testing.T) {
+	data := pngImage(t, 3, 2)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/image/abc" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	cache, err := NewImageCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := New(WithImageCache(cache))
+	img := Image{URL: server.URL + "/image/abc", Width: 640, Height: 640}
+
+	for i, cached := range []bool{false, true} {
+		var buf bytes.Buffer
+		info, err := client.DownloadImage(context.Background(), img, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Error("Expected the image's data to be written")
+		}
+		want := ImageInfo{ContentType: "image/png", Width: 3, Height: 2, Size: int64(len(data)), Cached: cached}
+		if *info != want {
+			t.Errorf("Download %d: expected %+v, got %+v", i, want, *info)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the image to be downloaded once, got %d requests", requests)
+	}
+
+	_, err = client.DownloadImage(context.Background(), Image{URL: server.URL + "/image/missing"}, &bytes.Buffer{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDownloadImageWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header, got %q", auth)
+		}
+		_, _ = w.Write(pngImage(t, 1, 1))
+	}))
+	defer server.Close()
+
+	// Requests sent to the Web API carry the user's token.
+	withToken := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Authorization", "Bearer secret")
+			return next.Do(req)
+		})
+	}
+	client := New(WithMiddleware(withToken), WithRateLimit(1, 1))
+	for i := 0; i < 3; i++ {
+		if _, err := client.DownloadImage(context.Background(), Image{URL: server.URL}, &bytes.Buffer{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := client.limiter.reserve(time.Now()); d > 0 {
+		t.Errorf("Expected downloads not to use the rate limit, got a %s wait", d)
+	}
+}
+
+func TestDownloadImageCacheError(t *testing.T) {
+	data := pngImage(t, 1, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "images")
+	cache, err := NewImageCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := New(WithImageCache(cache)).DownloadImage(context.Background(), Image{URL: server.URL}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Error("Expected the image's data to be written")
+	}
+}
+
+func TestDownloadImageRetry(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(pngImage(t, 1, 1))
+	}))
+	defer server.Close()
+
+	client := New(WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond}))
+	if _, err := client.DownloadImage(context.Background(), Image{URL: server.URL}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestDownloadImageUnknownFormat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	info, err := New().DownloadImage(context.Background(), Image{URL: server.URL, Width: 300, Height: 300}, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ContentType != "image/webp" || info.Width != 300 || info.Height != 300 {
+		t.Errorf("Expected the detected type and the given dimensions, got %+v", *info)
+	}
+}
+
+func TestBestImage(t *testing.T) {
+	images := []Image{
+		{URL: "large", Width: 640, Height: 640},
+		{URL: "small", Width: 64, Height: 64},
+		{URL: "medium", Width: 300, Height: 300},
+	}
+	tests := []struct {
+		width, height int
+		want          string
+	}{
+		{50, 50, "small"},
+		{64, 64, "small"},
+		{100, 100, "medium"},
+		{301, 10, "large"},
+		{1000, 1000, "large"},
+	}
+	for _, tt := range tests {
+		if img, ok := BestImage(images, tt.width, tt.height); !ok || img.URL != tt.want {
+			t.Errorf("BestImage(%dx%d) = %s, want %s", tt.width, tt.height, img.URL, tt.want)
+		}
+	}
+
+	if img, ok := BestImage([]Image{{URL: "unknown"}, {URL: "other"}}, 100, 100); !ok || img.URL != "unknown" {
+		t.Errorf("Expected the first image of unknown size, got %s", img.URL)
+	}
+	if _, ok := BestImage(nil, 100, 100); ok {
+		t.Error("Expected no image")
+	}
+}
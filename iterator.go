@@ -0,0 +1,167 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// iterable is implemented by every paging object, whether it is
+// offset-based or cursor-based.
+type iterable interface {
+	pageable
+	nextURL() string
+}
+
+func (b *basePage) nextURL() string   { return b.Next }
+func (c *cursorPage) nextURL() string { return c.Next }
+
+// PageIterator iterates over the individual items of a paging object,
+// fetching the following pages from the Web API as it goes.
+// A PageIterator must not be used from several goroutines at once.
+type PageIterator struct {
+	ctx    context.Context
+	client *Client
+
+	page  reflect.Value
+	items reflect.Value
+	index int
+	err   error
+}
+
+// Iterate returns an iterator over the items of p and of all the pages that
+// follow it.  p must be a pointer to one of the page types of this package,
+// such as *SavedTrackPage or *FullArtistCursorPage; it is never modified.
+//
+// Iteration stops at the first error, or as soon as ctx is done.
+// Callers may stop iterating at any time.
+//
+//	it := client.Iterate(ctx, page)
+//	for it.Next() {
+//		track := it.Item().(spotify.SavedTrack)
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+func (c *Client) Iterate(ctx context.Context, p iterable) *PageIterator {
+	it := &PageIterator{ctx: ctx, client: c, index: -1}
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		it.err = errors.New("spotify: p must be a non-nil pointer to a page")
+		return it
+	}
+	it.setPage(reflect.ValueOf(p))
+	return it
+}
+
+func (it *PageIterator) setPage(page reflect.Value) {
+	it.page = page
+	it.items = itemsOf(page)
+	if !it.items.IsValid() {
+		it.err = errors.New("spotify: p must be a pointer to a page of items")
+	}
+}
+
+// itemsOf returns the field holding the items of the page that p points to,
+// or the zero Value if there is no such field.
+func itemsOf(p reflect.Value) reflect.Value {
+	t := p.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("json") == "items" {
+			return p.Elem().Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed.  It returns false when there are no more items or an error occurred.
+func (it *PageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for it.index+1 >= it.items.Len() {
+		next := it.page.Interface().(iterable).nextURL()
+		if next == "" {
+			return false
+		}
+
+		page := reflect.New(it.page.Elem().Type())
+		newWrapper := it.page.Interface().(pageable).pageWrapping().newWrapper
+		if err := it.client.getPage(it.ctx, next, page.Interface().(pageable), newWrapper); err != nil {
+			it.err = err
+			return false
+		}
+		it.setPage(page)
+		if it.err != nil {
+			return false
+		}
+		it.index = -1
+	}
+
+	it.index++
+	return true
+}
+
+// Item returns the current item, such as a SavedTrack or a FullArtist,
+// depending on the type of page being iterated over.
+func (it *PageIterator) Item() interface{} {
+	if it.index < 0 || !it.items.IsValid() || it.index >= it.items.Len() {
+		return nil
+	}
+	return it.items.Index(it.index).Interface()
+}
+
+// Page returns the page that the current item belongs to.
+// It has the same type as the page passed to Iterate.
+func (it *PageIterator) Page() interface{} {
+	if !it.page.IsValid() {
+		return nil
+	}
+	return it.page.Interface()
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// wrappedPage is implemented by the objects that some endpoints, such as
+// search or the current user's followed artists, wrap their paging object in.
+type wrappedPage interface {
+	unwrapPage() pageable
+}
+
+// wrapping is embedded in every paging object.  It records how the endpoint
+// that returned the page wrapped it, so that the pages that follow it are
+// taken out of the same kind of object.
+type wrapping struct {
+	// newWrapper returns an empty wrapping object, or is nil if the
+	// page isn't wrapped.
+	newWrapper func() wrappedPage
+}
+
+func (w *wrapping) pageWrapping() *wrapping { return w }
+
+// getPage fetches the paging object at url into p.  If newWrapper isn't nil,
+// the response is decoded into the object it returns, and the paging object
+// is taken out of that.
+func (c *Client) getPage(ctx context.Context, url string, p pageable, newWrapper func() wrappedPage) error {
+	if newWrapper == nil {
+		return c.get(ctx, url, p)
+	}
+
+	w := newWrapper()
+	err := c.get(ctx, url, w)
+	if err != nil {
+		return err
+	}
+	reflect.ValueOf(p).Elem().Set(reflect.ValueOf(w.unwrapPage()).Elem())
+	p.pageWrapping().newWrapper = newWrapper
+	return nil
+}
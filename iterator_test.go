@@ -0,0 +1,155 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// pagedServer serves the bodies in order, one per request.  Occurrences of
+// %[1]s in a body are replaced with the server's URL.
+func pagedServer(t *testing.T, bodies ...string) (*Client, *httptest.Server, *int) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests >= len(bodies) {
+			t.Errorf("Unexpected request for %s", r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, bodies[requests], server.URL)
+		requests++
+	}))
+	return New(WithBaseURL(server.URL + "/")), server, &requests
+}
+
+func TestIterateOffsetPages(t *testing.T) {
+	client, server, requests := pagedServer(t,
+		`{"items": [{"track": {"id": "1"}}, {"track": {"id": "2"}}], "next": "%[1]s/me/tracks?offset=2"}`,
+		`{"items": [], "next": "%[1]s/me/tracks?offset=2"}`,
+		`{"items": [{"track": {"id": "3"}}], "next": null}`,
+	)
+	defer server.Close()
+
+	page, err := client.CurrentUsersTracks(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []ID
+	it := client.Iterate(context.Background(), page)
+	for it.Next() {
+		ids = append(ids, it.Item().(SavedTrack).ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Expected [1 2 3], got %v", ids)
+	}
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+	if len(page.Tracks) != 2 {
+		t.Error("The first page shouldn't have been modified")
+	}
+}
+
+func TestIterateCursorPages(t *testing.T) {
+	client, server, _ := pagedServer(t,
+		`{"artists": {"items": [{"id": "a"}], "next": "%[1]s/me/following?after=a", "cursors": {"after": "a"}}}`,
+		`{"artists": {"items": [{"id": "b"}], "next": null, "cursors": {"after": null}}}`,
+	)
+	defer server.Close()
+
+	page, err := client.CurrentUsersFollowedArtists(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []ID
+	it := client.Iterate(context.Background(), page)
+	for it.Next() {
+		ids = append(ids, it.Item().(FullArtist).ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[a b]" {
+		t.Errorf("Expected [a b], got %v", ids)
+	}
+}
+
+func TestIterateStopsWhenContextDone(t *testing.T) {
+	client, server, requests := pagedServer(t,
+		`{"items": [{"id": "1"}, {"id": "2"}], "next": "%[1]s/next"}`,
+	)
+	defer server.Close()
+
+	page, err := client.GetPlaylistsForUser(context.Background(), "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := client.Iterate(ctx, page)
+	if !it.Next() {
+		t.Fatal("Expected a first item")
+	}
+	cancel()
+	if it.Next() {
+		t.Error("Expected iteration to stop")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+	if *requests != 1 {
+		t.Errorf("Expected no further requests, got %d", *requests-1)
+	}
+}
+
+func TestIterateWrappedPages(t *testing.T) {
+	client, server, _ := pagedServer(t,
+		`{"message": "Monday morning music", "playlists": {"items": [{"id": "1"}], "next": "%[1]s/browse/featured-playlists?offset=1"}}`,
+		`{"message": "Monday morning music", "playlists": {"items": [{"id": "2"}], "next": null}}`,
+	)
+	defer server.Close()
+
+	_, page, err := client.FeaturedPlaylists(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []ID
+	it := client.Iterate(context.Background(), page)
+	for it.Next() {
+		ids = append(ids, it.Item().(SimplePlaylist).ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(ids) != "[1 2]" {
+		t.Errorf("Expected [1 2], got %v", ids)
+	}
+}
+
+func TestNextPageSearchResult(t *testing.T) {
+	client, server, _ := pagedServer(t,
+		`{"tracks": {"items": [{"id": "1"}], "next": "%[1]s/search?type=track&offset=1"}}`,
+		`{"tracks": {"items": [{"id": "2"}], "next": "%[1]s/search?type=track&offset=2"}}`,
+	)
+	defer server.Close()
+
+	result, err := client.Search(context.Background(), "timber", SearchTypeTrack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.NextPage(context.Background(), result.Tracks); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks.Tracks) != 1 || result.Tracks.Tracks[0].ID != "2" {
+		t.Errorf("Expected the second page of tracks, got %v", result.Tracks.Tracks)
+	}
+}
@@ -50,6 +50,63 @@ func (c *Client) RemoveAlbumsFromLibrary(ctx context.Context, ids ...ID) error {
 	return c.modifyLibrary(ctx, "albums", false, ids...)
 }
 
+// UserHasShows checks if one or more shows are saved to the current user's
+// "Your Podcasts" library.
+func (c *Client) UserHasShows(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContains(ctx, "shows", ids...)
+}
+
+// AddShowsToLibrary saves one or more shows to the current user's
+// "Your Podcasts" library.  This call requires the ScopeUserLibraryModify scope.
+// A show can only be saved once; duplicate IDs are ignored.
+func (c *Client) AddShowsToLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "shows", true, ids...)
+}
+
+// RemoveShowsFromLibrary removes one or more shows from the current user's
+// "Your Podcasts" library.  This call requires the ScopeUserLibraryModify scope.
+func (c *Client) RemoveShowsFromLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "shows", false, ids...)
+}
+
+// UserHasEpisodes checks if one or more episodes are saved to the current
+// user's "Your Episodes" library.
+func (c *Client) UserHasEpisodes(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContains(ctx, "episodes", ids...)
+}
+
+// AddEpisodesToLibrary saves one or more episodes to the current user's
+// "Your Episodes" library.  This call requires the ScopeUserLibraryModify scope.
+// An episode can only be saved once; duplicate IDs are ignored.
+func (c *Client) AddEpisodesToLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "episodes", true, ids...)
+}
+
+// RemoveEpisodesFromLibrary removes one or more episodes from the current
+// user's "Your Episodes" library.  This call requires the ScopeUserLibraryModify scope.
+func (c *Client) RemoveEpisodesFromLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "episodes", false, ids...)
+}
+
+// UserHasAudiobooks checks if one or more audiobooks are saved to the current
+// user's library.
+func (c *Client) UserHasAudiobooks(ctx context.Context, ids ...ID) ([]bool, error) {
+	return c.libraryContains(ctx, "audiobooks", ids...)
+}
+
+// AddAudiobooksToLibrary saves one or more audiobooks to the current user's
+// library.  This call requires the ScopeUserLibraryModify scope.
+// An audiobook can only be saved once; duplicate IDs are ignored.
+func (c *Client) AddAudiobooksToLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "audiobooks", true, ids...)
+}
+
+// RemoveAudiobooksFromLibrary removes one or more audiobooks from the current
+// user's library.  This call requires the ScopeUserLibraryModify scope.
+func (c *Client) RemoveAudiobooksFromLibrary(ctx context.Context, ids ...ID) error {
+	return c.modifyLibrary(ctx, "audiobooks", false, ids...)
+}
+
 func (c *Client) modifyLibrary(ctx context.Context, typ string, add bool, ids ...ID) error {
 	if l := len(ids); l == 0 || l > 50 {
 		return errors.New("spotify: this call supports 1 to 50 IDs per call")
@@ -107,3 +107,142 @@ func TestRemoveAlbumsFromLibrary(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestUserHasEpisodes(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[ true, false ]`, func(req *http.Request) {
+		if req.URL.Path != "/me/episodes/contains" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	contains, err := client.UserHasEpisodes(context.Background(), "512ojhOuo1ktJprKbVcKyQ", "0000000000000000000000")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(contains) != 2 || !contains[0] || contains[1] {
+		t.Error("Expected [true, false], got", contains)
+	}
+}
+
+func TestAddEpisodesToLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/me/episodes" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.AddEpisodesToLibrary(context.Background(), "512ojhOuo1ktJprKbVcKyQ")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveEpisodesFromLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodDelete || req.URL.Path != "/me/episodes" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.RemoveEpisodesFromLibrary(context.Background(), "512ojhOuo1ktJprKbVcKyQ")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserHasShows(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[ false, true ]`, func(req *http.Request) {
+		if req.URL.Path != "/me/shows/contains" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	contains, err := client.UserHasShows(context.Background(), "5CfCWKI5pZ28U0uOzXkDHe", "5as3aKmN2k11yfDDDSrvaZ")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(contains) != 2 || contains[0] || !contains[1] {
+		t.Error("Expected [false, true], got", contains)
+	}
+}
+
+func TestAddShowsToLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/me/shows" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.AddShowsToLibrary(context.Background(), "5CfCWKI5pZ28U0uOzXkDHe")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveShowsFromLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodDelete || req.URL.Path != "/me/shows" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.RemoveShowsFromLibrary(context.Background(), "5CfCWKI5pZ28U0uOzXkDHe")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := client.RemoveShowsFromLibrary(context.Background(), make([]ID, 51)...); err == nil {
+		t.Error("Expected an error for more than 50 IDs")
+	}
+}
+
+func TestUserHasAudiobooks(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[ true ]`, func(req *http.Request) {
+		if req.URL.Path != "/me/audiobooks/contains" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	contains, err := client.UserHasAudiobooks(context.Background(), "7iHfbu1YPACw6oZPAFJtqe")
+	if err != nil {
+		t.Error(err)
+	}
+	if len(contains) != 1 || !contains[0] {
+		t.Error("Expected [true], got", contains)
+	}
+}
+
+func TestAddAudiobooksToLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/me/audiobooks" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.AddAudiobooksToLibrary(context.Background(), "7iHfbu1YPACw6oZPAFJtqe")
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveAudiobooksFromLibrary(t *testing.T) {
+	client, server := testClientString(http.StatusOK, "", func(req *http.Request) {
+		if req.Method != http.MethodDelete || req.URL.Path != "/me/audiobooks" {
+			t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	err := client.RemoveAudiobooksFromLibrary(context.Background(), "7iHfbu1YPACw6oZPAFJtqe")
+	if err != nil {
+		t.Error(err)
+	}
+}
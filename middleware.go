@@ -0,0 +1,126 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Doer sends an HTTP request and returns an HTTP response.
+// *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doers.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer used to send requests to the Spotify API,
+// allowing requests and responses to be inspected or modified.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the chain every request goes through.
+// The first middleware given is the outermost one: it sees requests first
+// and responses last.  Middleware is invoked once per attempt, so retried
+// requests pass through it several times.
+func WithMiddleware(middleware ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.middleware = append(client.middleware, middleware...)
+	}
+}
+
+// doer returns the client's HTTP client wrapped in all of its middleware.
+func (c *Client) doer() Doer {
+	var d Doer = c.http
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
+
+type attemptKey struct{}
+
+// RequestAttempt returns which attempt at sending a request ctx belongs to,
+// starting from 1.  It is intended for middleware, and returns 0 when ctx
+// doesn't come from a request sent by a Client.
+func RequestAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+// UserAgentMiddleware sets the User-Agent header of every request.  The header
+// names product, if it isn't empty, followed by this library and its Version.
+func UserAgentMiddleware(product string) Middleware {
+	ua := "spotify-go/" + Version
+	if product != "" {
+		ua = product + " " + ua
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			req.Header.Set("User-Agent", ua)
+			return next.Do(req)
+		})
+	}
+}
+
+// RequestLog describes a single attempt at sending a request to the Spotify API.
+type RequestLog struct {
+	// Method is the HTTP method of the request.
+	Method string
+	// Path is the path of the request's URL, without the query string.
+	Path string
+	// Status is the HTTP status code of the response,
+	// or 0 if no response was received.
+	Status int
+	// Latency is the time it took to receive the response headers.
+	Latency time.Duration
+	// Retry is 0 for the first attempt at sending a request,
+	// and is incremented for each retry.
+	Retry int
+	// Err is the error returned while sending the request, if any.
+	Err error
+}
+
+func (l RequestLog) String() string {
+	s := fmt.Sprintf("method=%s path=%s status=%d latency=%s retry=%d", l.Method, l.Path, l.Status, l.Latency, l.Retry)
+	if l.Err != nil {
+		s += fmt.Sprintf(" error=%q", l.Err.Error())
+	}
+	return s
+}
+
+// LoggingMiddleware calls log once for every request sent, including retries.
+// For example, to write requests to the standard logger:
+//
+//	spotify.WithMiddleware(spotify.LoggingMiddleware(func(l spotify.RequestLog) { log.Print(l) }))
+func LoggingMiddleware(log func(RequestLog)) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			entry := RequestLog{
+				Method:  req.Method,
+				Path:    req.URL.Path,
+				Latency: time.Since(start),
+				Err:     err,
+			}
+			if attempt := RequestAttempt(req.Context()); attempt > 0 {
+				entry.Retry = attempt - 1
+			}
+			if resp != nil {
+				entry.Status = resp.StatusCode
+			}
+			log(entry)
+
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[ true ]`, func(r *http.Request) {
+		if got := r.Header.Get("X-Order"); got != "outer,inner" {
+			t.Errorf("Expected middleware to run outermost first, got %q", got)
+		}
+	})
+	defer server.Close()
+
+	tag := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				if v := req.Header.Get("X-Order"); v != "" {
+					name = v + "," + name
+				}
+				req.Header.Set("X-Order", name)
+				return next.Do(req)
+			})
+		}
+	}
+	WithMiddleware(tag("outer"), tag("inner"))(client)
+
+	if _, err := client.UserHasTracks(context.Background(), "0udZHhCi7p1YzMlvI4fXoK"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserAgentMiddleware(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[ true ]`, func(r *http.Request) {
+		if got, want := r.Header.Get("User-Agent"), "my-app/2.0 spotify-go/"+Version; got != want {
+			t.Errorf("Expected User-Agent %q, got %q", want, got)
+		}
+	})
+	defer server.Close()
+	WithMiddleware(UserAgentMiddleware("my-app/2.0"))(client)
+
+	if _, err := client.UserHasTracks(context.Background(), "0udZHhCi7p1YzMlvI4fXoK"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var logs []RequestLog
+	client := New(
+		WithBaseURL(server.URL+"/"),
+		WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond}),
+		WithMiddleware(LoggingMiddleware(func(l RequestLog) {
+			logs = append(logs, l)
+		})),
+	)
+	if err := client.Pause(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 log entries, got %d", len(logs))
+	}
+	for i, status := range []int{http.StatusServiceUnavailable, http.StatusNoContent} {
+		l := logs[i]
+		if l.Method != http.MethodPut || l.Path != "/me/player/pause" || l.Status != status || l.Retry != i {
+			t.Errorf("Unexpected log entry %d: %v", i, l)
+		}
+	}
+	if s := logs[1].String(); !strings.HasPrefix(s, "method=PUT path=/me/player/pause status=204 ") {
+		t.Errorf("Unexpected formatting: %s", s)
+	}
+}
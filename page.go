@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strconv"
+	"sync"
 )
 
 // ErrNoMorePages is the error returned when you attempt to get the next
@@ -33,6 +36,8 @@ type basePage struct {
 	Next string `json:"next"`
 	// The URL to the previous page of items (if available).
 	Previous string `json:"previous"`
+
+	wrapping
 }
 
 // FullArtistPage contains FullArtists returned by the Web API.
@@ -95,20 +100,54 @@ type CategoryPage struct {
 	Categories []Category `json:"items"`
 }
 
-// SimpleEpisodePage contains EpisodePage returned by the Web API.
+// SimpleEpisodePage contains the episodes of a show returned by the Web API.
+// The Show field of the episodes is not set.
 type SimpleEpisodePage struct {
 	basePage
-	Episodes []EpisodePage `json:"items"`
+	Episodes []FullEpisode `json:"items"`
+}
+
+// SavedEpisodePage contains SavedEpisodes returned by the Web API.
+type SavedEpisodePage struct {
+	basePage
+	Episodes []SavedEpisode `json:"items"`
+}
+
+// SimpleAudiobookPage contains SimpleAudiobooks returned by the Web API.
+type SimpleAudiobookPage struct {
+	basePage
+	Audiobooks []SimpleAudiobook `json:"items"`
+}
+
+// ChapterPage contains Chapters returned by the Web API.
+type ChapterPage struct {
+	basePage
+	Chapters []Chapter `json:"items"`
 }
 
 // pageable is an internal interface for types that support paging
-// by embedding basePage.
-type pageable interface{ canPage() }
+// by embedding basePage or cursorPage.
+type pageable interface {
+	canPage()
+	pageWrapping() *wrapping
+}
 
 func (b *basePage) canPage() {}
 
+// offsetPageable is an internal interface for types that support
+// random access paging by embedding basePage.
+type offsetPageable interface {
+	pageable
+	offsetPage() *basePage
+}
+
+func (b *basePage) offsetPage() *basePage { return b }
+
 // NextPage fetches the next page of items and writes them into p.
 // It returns ErrNoMorePages if p already contains the last page.
+//
+// Cursor-based pages, such as FullArtistCursorPage, are advanced
+// by following their cursor.
 func (c *Client) NextPage(ctx context.Context, p pageable) error {
 	if p == nil || reflect.ValueOf(p).IsNil() {
 		return fmt.Errorf("spotify: p must be a non-nil pointer to a page")
@@ -121,6 +160,7 @@ func (c *Client) NextPage(ctx context.Context, p pageable) error {
 	if len(nextURL) == 0 {
 		return ErrNoMorePages
 	}
+	newWrapper := p.pageWrapping().newWrapper
 
 	// Zero out the page so that we can overwrite it in the next
 	// call to get. This is necessary because encoding/json does
@@ -128,11 +168,14 @@ func (c *Client) NextPage(ctx context.Context, p pageable) error {
 	zero := reflect.Zero(val.Type())
 	val.Set(zero)
 
-	return c.get(ctx, nextURL, p)
+	return c.getPage(ctx, nextURL, p, newWrapper)
 }
 
 // PreviousPage fetches the previous page of items and writes them into p.
-// It returns ErrNoMorePages if p already contains the last page.
+// It returns ErrNoMorePages if p already contains the first page.
+//
+// Cursor-based pages can only be read forwards, so PreviousPage
+// always returns ErrNoMorePages for them.
 func (c *Client) PreviousPage(ctx context.Context, p pageable) error {
 	if p == nil || reflect.ValueOf(p).IsNil() {
 		return fmt.Errorf("spotify: p must be a non-nil pointer to a page")
@@ -140,11 +183,15 @@ func (c *Client) PreviousPage(ctx context.Context, p pageable) error {
 
 	val := reflect.ValueOf(p).Elem()
 	field := val.FieldByName("Previous")
+	if !field.IsValid() {
+		return ErrNoMorePages
+	}
 	prevURL := field.Interface().(string)
 
 	if len(prevURL) == 0 {
 		return ErrNoMorePages
 	}
+	newWrapper := p.pageWrapping().newWrapper
 
 	// Zero out the page so that we can overwrite it in the next
 	// call to get. This is necessary because encoding/json does
@@ -152,5 +199,89 @@ func (c *Client) PreviousPage(ctx context.Context, p pageable) error {
 	zero := reflect.Zero(val.Type())
 	val.Set(zero)
 
-	return c.get(ctx, prevURL, p)
+	return c.getPage(ctx, prevURL, p, newWrapper)
+}
+
+// FetchAll fetches every page that follows p and appends their items to the
+// items of p, in order.  The pages are fetched concurrently; see WithConcurrency.
+// This works with any offset-based page, such as those returned by
+// CurrentUsersTracks, GetPlaylistTracks or GetArtistAlbums.
+//
+// On success, p holds all the items from its offset onwards and has no next page.
+// On failure, p is left untouched.
+func (c *Client) FetchAll(ctx context.Context, p offsetPageable) error {
+	if p == nil || reflect.ValueOf(p).IsNil() {
+		return fmt.Errorf("spotify: p must be a non-nil pointer to a page")
+	}
+	val := reflect.ValueOf(p)
+	items := itemsOf(val)
+	if !items.IsValid() {
+		return errors.New("spotify: p must be a pointer to a page of items")
+	}
+
+	base := p.offsetPage()
+	if base.Next == "" {
+		return nil
+	}
+	next, err := url.Parse(base.Next)
+	if err != nil {
+		return err
+	}
+	limit := base.Limit
+	if limit <= 0 {
+		limit = items.Len()
+	}
+	if limit <= 0 {
+		return errors.New("spotify: can't fetch pages of unknown size")
+	}
+
+	var offsets []int
+	for offset := base.Offset + limit; offset < base.Total; offset += limit {
+		offsets = append(offsets, offset)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	pages := make([]reflect.Value, len(offsets))
+	errs := c.parallel(ctx, len(offsets), func(ctx context.Context, i int) error {
+		u := *next
+		q := u.Query()
+		q.Set("offset", strconv.Itoa(offsets[i]))
+		q.Set("limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+
+		page := reflect.New(val.Elem().Type())
+		err := c.getPage(ctx, u.String(), page.Interface().(pageable), base.newWrapper)
+		if err != nil {
+			once.Do(func() {
+				firstErr = err
+				cancel()
+			})
+			return err
+		}
+		pages[i] = page
+		return nil
+	})
+	if firstErr != nil {
+		return firstErr
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	all := items
+	for _, page := range pages {
+		all = reflect.AppendSlice(all, itemsOf(page))
+	}
+	items.Set(all)
+	base.Next = ""
+	base.Limit = all.Len()
+
+	return nil
 }
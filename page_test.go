@@ -3,8 +3,12 @@ package spotify
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -117,3 +121,83 @@ func TestClient_PreviousPage(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_NextPageCursor(t *testing.T) {
+	client, server := testClientString(200, `{"artists": {"items": [{"id": "b"}], "next": null, "cursors": {"after": null}}}`, func(request *http.Request) {
+		assert.Equal(t, "/v1/me/following?type=artist&after=a", request.URL.RequestURI())
+	})
+	defer server.Close()
+
+	page := &FullArtistCursorPage{
+		cursorPage: cursorPage{
+			Next:     server.URL + "/v1/me/following?type=artist&after=a",
+			Cursor:   Cursor{After: "a"},
+			wrapping: wrapping{newWrapper: func() wrappedPage { return new(followedArtists) }},
+		},
+		Artists: []FullArtist{{SimpleArtist: SimpleArtist{ID: "a"}}},
+	}
+
+	err := client.NextPage(context.Background(), page)
+	assert.NoError(t, err)
+	assert.Len(t, page.Artists, 1)
+	assert.Equal(t, ID("b"), page.Artists[0].ID)
+	assert.Equal(t, "", page.Cursor.After)
+
+	assert.Equal(t, ErrNoMorePages, client.NextPage(context.Background(), page))
+	assert.Equal(t, ErrNoMorePages, client.PreviousPage(context.Background(), page))
+}
+
+func TestClient_FetchAll(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "/v1/me/tracks", r.URL.Path)
+		assert.Equal(t, "2", q.Get("limit"))
+		assert.Equal(t, "ES", q.Get("market"))
+
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		var items []string
+		for i := offset; i < offset+2 && i < 7; i++ {
+			items = append(items, fmt.Sprintf(`{"track": {"id": "%d"}}`, i))
+		}
+		fmt.Fprintf(w, `{"items": [%s], "limit": 2, "offset": %d, "total": 7}`, strings.Join(items, ","), offset)
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL+"/"), WithConcurrency(2))
+
+	page := &SavedTrackPage{
+		basePage: basePage{
+			Limit: 2,
+			Total: 7,
+			Next:  server.URL + "/v1/me/tracks?offset=2&limit=2&market=ES",
+		},
+		Tracks: []SavedTrack{
+			{FullTrack: FullTrack{SimpleTrack: SimpleTrack{ID: "0"}}},
+			{FullTrack: FullTrack{SimpleTrack: SimpleTrack{ID: "1"}}},
+		},
+	}
+
+	err := client.FetchAll(context.Background(), page)
+	assert.NoError(t, err)
+	if assert.Len(t, page.Tracks, 7) {
+		for i, track := range page.Tracks {
+			assert.Equal(t, ID(strconv.Itoa(i)), track.ID)
+		}
+	}
+	assert.Equal(t, "", page.Next)
+	assert.Equal(t, ErrNoMorePages, client.NextPage(context.Background(), page))
+}
+
+func TestClient_FetchAllError(t *testing.T) {
+	client, server := testClientString(http.StatusNotFound, "")
+	defer server.Close()
+
+	page := &SimpleAlbumPage{
+		basePage: basePage{Limit: 1, Total: 3, Next: server.URL + "/v1/artists/x/albums?offset=1"},
+		Albums:   []SimpleAlbum{{ID: "0"}},
+	}
+	err := client.FetchAll(context.Background(), page)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Len(t, page.Albums, 1)
+	assert.NotEqual(t, "", page.Next)
+}
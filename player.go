@@ -38,13 +38,32 @@ type PlayerState struct {
 	RepeatState string `json:"repeat_state"`
 }
 
+// UnmarshalJSON decodes the player state.  It is needed because
+// the method of the embedded CurrentlyPlaying would hide the other fields.
+func (ps *PlayerState) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &ps.CurrentlyPlaying); err != nil {
+		return err
+	}
+	var v struct {
+		Device       PlayerDevice `json:"device"`
+		ShuffleState bool         `json:"shuffle_state"`
+		RepeatState  string       `json:"repeat_state"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	ps.Device, ps.ShuffleState, ps.RepeatState = v.Device, v.ShuffleState, v.RepeatState
+	return nil
+}
+
 // PlaybackContext is the playback context
 type PlaybackContext struct {
 	// ExternalURLs of the context, or null if not available.
 	ExternalURLs map[string]string `json:"external_urls"`
 	// Endpoint of the context, or null if not available.
 	Endpoint string `json:"href"`
-	// Type of the item's context. Can be one of album, artist or playlist.
+	// Type of the item's context. Can be one of album, artist, playlist,
+	// show or audiobook.
 	Type string `json:"type"`
 	// URI is the Spotify URI for the context.
 	URI URI `json:"uri"`
@@ -60,8 +79,54 @@ type CurrentlyPlaying struct {
 	Progress int `json:"progress_ms"`
 	// Playing If something is currently playing.
 	Playing bool `json:"is_playing"`
-	// The currently playing track. Can be null.
+	// The currently playing track. Can be null.  It is also nil when
+	// something other than a track is playing, see CurrentlyPlayingType.
 	Item *FullTrack `json:"item"`
+	// The currently playing episode of a show, if any.  Episodes are only
+	// reported when requested with the AdditionalTypes option.
+	Episode *FullEpisode `json:"-"`
+	// The currently playing chapter of an audiobook, if any.  Chapters are
+	// only reported when requested with the AdditionalTypes option.
+	Chapter *Chapter `json:"-"`
+	// The type of the currently playing item: track, episode, ad or
+	// unknown.  Chapters of audiobooks are reported as episodes.
+	CurrentlyPlayingType string `json:"currently_playing_type"`
+}
+
+// UnmarshalJSON decodes the currently playing item into Item, Episode
+// or Chapter, depending on its type.
+func (cp *CurrentlyPlaying) UnmarshalJSON(data []byte) error {
+	type currentlyPlaying CurrentlyPlaying
+	var v struct {
+		currentlyPlaying
+		Item json.RawMessage `json:"item"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*cp = CurrentlyPlaying(v.currentlyPlaying)
+	if len(v.Item) == 0 || string(v.Item) == "null" {
+		return nil
+	}
+
+	var item struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(v.Item, &item); err != nil {
+		return err
+	}
+	switch item.Type {
+	case "track", "":
+		cp.Item = new(FullTrack)
+		return json.Unmarshal(v.Item, cp.Item)
+	case "episode":
+		cp.Episode = new(FullEpisode)
+		return json.Unmarshal(v.Item, cp.Episode)
+	case "chapter":
+		cp.Chapter = new(Chapter)
+		return json.Unmarshal(v.Item, cp.Chapter)
+	}
+	return nil
 }
 
 type RecentlyPlayedItem struct {
@@ -75,7 +140,11 @@ type RecentlyPlayedItem struct {
 	PlaybackContext PlaybackContext `json:"context"`
 }
 
+// RecentlyPlayedResult is a cursor-based paging object containing
+// a set of RecentlyPlayedItem objects.  Its Next page holds the
+// items played before these ones.
 type RecentlyPlayedResult struct {
+	cursorPage
 	Items []RecentlyPlayedItem `json:"items"`
 }
 
@@ -146,7 +215,7 @@ func (c *Client) PlayerDevices(ctx context.Context) ([]PlayerDevice, error) {
 // PlayerState gets information about the playing state for the current user
 // Requires the ScopeUserReadPlaybackState scope in order to read information
 //
-// Supported options: Market
+// Supported options: AdditionalTypes, Market
 func (c *Client) PlayerState(ctx context.Context, opts ...RequestOption) (*PlayerState, error) {
 	spotifyURL := c.baseURL + "me/player"
 	if params := processOptions(opts...).urlParams.Encode(); params != "" {
@@ -169,7 +238,7 @@ func (c *Client) PlayerState(ctx context.Context, opts ...RequestOption) (*Playe
 // Requires the ScopeUserReadCurrentlyPlaying scope or the ScopeUserReadPlaybackState
 // scope in order to read information
 //
-// Supported options: Market
+// Supported options: AdditionalTypes, Market
 func (c *Client) PlayerCurrentlyPlaying(ctx context.Context, opts ...RequestOption) (*CurrentlyPlaying, error) {
 	spotifyURL := c.baseURL + "me/player/currently-playing"
 
@@ -200,6 +269,19 @@ func (c *Client) PlayerRecentlyPlayed(ctx context.Context) ([]RecentlyPlayedItem
 // PlayerRecentlyPlayedOpt is like PlayerRecentlyPlayed, but it accepts
 // additional options for sorting and filtering the results.
 func (c *Client) PlayerRecentlyPlayedOpt(ctx context.Context, opt *RecentlyPlayedOptions) ([]RecentlyPlayedItem, error) {
+	result, err := c.PlayerRecentlyPlayedPage(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
+// PlayerRecentlyPlayedPage is like PlayerRecentlyPlayedOpt, but it returns
+// the paging object so that older items can be fetched with NextPage.
+// To fetch items played after this page, pass its Cursor.After value,
+// parsed as an integer, as the AfterEpochMs option.
+func (c *Client) PlayerRecentlyPlayedPage(ctx context.Context, opt *RecentlyPlayedOptions) (*RecentlyPlayedResult, error) {
 	spotifyURL := c.baseURL + "me/player/recently-played"
 	if opt != nil {
 		v := url.Values{}
@@ -217,13 +299,13 @@ func (c *Client) PlayerRecentlyPlayedOpt(ctx context.Context, opt *RecentlyPlaye
 		}
 	}
 
-	result := RecentlyPlayedResult{}
+	var result RecentlyPlayedResult
 	err := c.get(ctx, spotifyURL, &result)
 	if err != nil {
 		return nil, err
 	}
 
-	return result.Items, nil
+	return &result, nil
 }
 
 // TransferPlayback transfers playback to a new device and determine if
@@ -341,11 +423,11 @@ func (c *Client) QueueSong(ctx context.Context, trackID ID) error {
 //
 // Only expects PlayOptions.DeviceID, all other options will be ignored
 func (c *Client) QueueSongOpt(ctx context.Context, trackID ID, opt *PlayOptions) error {
-	uri := "spotify:track:" + trackID
+	uri := NewURI(KindTrack, trackID)
 	spotifyURL := c.baseURL + "me/player/queue"
 	v := url.Values{}
 
-	v.Set("uri", uri.String())
+	v.Set("uri", string(uri))
 
 	if opt != nil {
 		if opt.DeviceID != nil {
@@ -387,7 +469,7 @@ func (c *Client) NextOpt(ctx context.Context, opt *PlayOptions) error {
 			spotifyURL += "?" + params
 		}
 	}
-	req, err := http.NewRequest(http.MethodPost, spotifyURL, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spotifyURL, nil)
 	if err != nil {
 		return err
 	}
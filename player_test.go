@@ -128,6 +128,81 @@ func TestPlayerCurrentlyPlaying(t *testing.T) {
 	}
 }
 
+func TestPlayerCurrentlyPlayingAudiobook(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{
+  "timestamp": 1700000000000,
+  "context": {
+    "external_urls": {"spotify": "https://open.spotify.com/audiobook/7iHfbu1YPACw6oZPAFJtqe"},
+    "href": "https://api.spotify.com/v1/audiobooks/7iHfbu1YPACw6oZPAFJtqe",
+    "type": "audiobook",
+    "uri": "spotify:audiobook:7iHfbu1YPACw6oZPAFJtqe"
+  },
+  "progress_ms": 5000,
+  "item": {
+    "id": "0D5wENdkdwbqlrHoaJ9g29",
+    "name": "Chapter 1",
+    "chapter_number": 1,
+    "type": "chapter",
+    "audiobook": {"id": "7iHfbu1YPACw6oZPAFJtqe", "name": "Dune"}
+  },
+  "currently_playing_type": "episode",
+  "is_playing": true
+}`, func(req *http.Request) {
+		if typ := req.URL.Query().Get("additional_types"); typ != "episode" {
+			t.Errorf("Expected additional_types=episode, got %q", typ)
+		}
+	})
+	defer server.Close()
+
+	state, err := client.PlayerCurrentlyPlaying(context.Background(), AdditionalTypes("episode"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Item != nil || state.Episode != nil {
+		t.Errorf("Expected no track or episode, got %+v and %+v", state.Item, state.Episode)
+	}
+	if state.Chapter == nil || state.Chapter.ChapterNumber != 1 || state.Chapter.Audiobook.Name != "Dune" {
+		t.Fatalf("Expected the playing chapter, got %+v", state.Chapter)
+	}
+	if !state.Playing || state.Progress != 5000 || state.CurrentlyPlayingType != "episode" {
+		t.Errorf("Unexpected state %+v", state)
+	}
+	r, err := state.PlaybackContext.URI.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != KindAudiobook || r.ID != "7iHfbu1YPACw6oZPAFJtqe" {
+		t.Errorf("Unexpected context %+v", r)
+	}
+}
+
+func TestPlayerStateEpisode(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{
+  "device": {"id": "device", "is_active": true, "name": "Phone"},
+  "shuffle_state": true,
+  "repeat_state": "context",
+  "progress_ms": 1000,
+  "item": `+episodeJSON+`,
+  "currently_playing_type": "episode",
+  "is_playing": true
+}`)
+	defer server.Close()
+
+	state, err := client.PlayerState(context.Background(), AdditionalTypes("episode"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Item != nil {
+		t.Error("Expected the episode not to be decoded as a track")
+	}
+	if state.Episode == nil || state.Episode.Show.Name != "Vetenskapsradion Historia" {
+		t.Errorf("Expected the playing episode, got %+v", state.Episode)
+	}
+	if state.Device.Name != "Phone" || !state.ShuffleState || state.RepeatState != "context" || state.Progress != 1000 {
+		t.Errorf("Unexpected state %+v", state)
+	}
+}
+
 func TestPlayerRecentlyPlayed(t *testing.T) {
 	client, server := testClientFile(http.StatusOK, "test_data/player_recently_played.txt")
 	defer server.Close()
@@ -149,6 +224,26 @@ func TestPlayerRecentlyPlayed(t *testing.T) {
 	}
 }
 
+func TestPlayerRecentlyPlayedPage(t *testing.T) {
+	client, server := testClientFile(http.StatusOK, "test_data/player_recently_played.txt")
+	defer server.Close()
+
+	page, err := client.PlayerRecentlyPlayedPage(context.Background(), &RecentlyPlayedOptions{Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page.Items) != 20 {
+		t.Error("Too few or too many items were returned")
+	}
+	if page.Cursor.After != "1495915674720" || page.Cursor.Before != "1495842394544" {
+		t.Errorf("Unexpected cursors: %+v", page.Cursor)
+	}
+	if page.Next != "https://api.spotify.com/v1/me/player/recently-played?before=1495842394544&type=track" {
+		t.Errorf("Unexpected next URL: %s", page.Next)
+	}
+}
+
 func TestPlayArgsError(t *testing.T) {
 	json := `{
 		"error" : {
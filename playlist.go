@@ -64,19 +64,25 @@ func (c *Client) FeaturedPlaylists(ctx context.Context, opts ...RequestOption) (
 		spotifyURL += "?" + params
 	}
 
-	var result struct {
-		Playlists SimplePlaylistPage `json:"playlists"`
-		Message   string             `json:"message"`
-	}
+	var result featuredPlaylists
 
 	err := c.get(ctx, spotifyURL, &result)
 	if err != nil {
 		return "", nil, err
 	}
+	result.Playlists.newWrapper = func() wrappedPage { return new(featuredPlaylists) }
 
 	return result.Message, &result.Playlists, nil
 }
 
+// featuredPlaylists is the object that the featured playlists are wrapped in.
+type featuredPlaylists struct {
+	Playlists SimplePlaylistPage `json:"playlists"`
+	Message   string             `json:"message"`
+}
+
+func (f *featuredPlaylists) unwrapPage() pageable { return &f.Playlists }
+
 // FollowPlaylist adds the current user as a follower of the specified
 // playlist.  Any playlist can be followed, regardless of its private/public
 // status, as long as you know the owner and playlist ID.
@@ -306,7 +312,7 @@ func (c *Client) modifyPlaylist(ctx context.Context, playlistID ID, newName, new
 func (c *Client) AddTracksToPlaylist(ctx context.Context, playlistID ID, trackIDs ...ID) (snapshotID string, err error) {
 	uris := make([]string, len(trackIDs))
 	for i, id := range trackIDs {
-		uris[i] = fmt.Sprintf("spotify:track:%s", id)
+		uris[i] = string(NewURI(KindTrack, id))
 	}
 	m := make(map[string]interface{})
 	m["uris"] = uris
@@ -348,7 +354,7 @@ func (c *Client) RemoveTracksFromPlaylist(ctx context.Context, playlistID ID, tr
 	}, len(trackIDs))
 
 	for i, u := range trackIDs {
-		tracks[i].URI = fmt.Sprintf("spotify:track:%s", u)
+		tracks[i].URI = string(NewURI(KindTrack, u))
 	}
 	return c.removeTracksFromPlaylist(ctx, playlistID, tracks, "")
 }
@@ -365,7 +371,7 @@ type TrackToRemove struct {
 // track ID and playlist locations.
 func NewTrackToRemove(trackID string, positions []int) TrackToRemove {
 	return TrackToRemove{
-		URI:       fmt.Sprintf("spotify:track:%s", trackID),
+		URI:       string(NewURI(KindTrack, ID(trackID))),
 		Positions: positions,
 	}
 }
@@ -440,7 +446,7 @@ func (c *Client) removeTracksFromPlaylist(
 func (c *Client) ReplacePlaylistTracks(ctx context.Context, playlistID ID, trackIDs ...ID) error {
 	trackURIs := make([]string, len(trackIDs))
 	for i, u := range trackIDs {
-		trackURIs[i] = fmt.Sprintf("spotify:track:%s", u)
+		trackURIs[i] = string(NewURI(KindTrack, u))
 	}
 	spotifyURL := fmt.Sprintf("%splaylists/%s/tracks?uris=%s",
 		c.baseURL, playlistID, strings.Join(trackURIs, ","))
@@ -539,12 +545,16 @@ func (c *Client) ReorderPlaylistTracks(ctx context.Context, playlistID ID, opt P
 // SetPlaylistImage replaces the image used to represent a playlist.
 // This action can only be performed by the owner of the playlist,
 // and requires ScopeImageUpload as well as ScopeModifyPlaylist{Public|Private}..
+// img must be a JPEG image no larger than MaxPlaylistImageSize once base64
+// encoded; use SetPlaylistImageFromImage to have it encoded for you.
 func (c *Client) SetPlaylistImage(ctx context.Context, playlistID ID, img io.Reader) error {
 	spotifyURL := fmt.Sprintf("%splaylists/%s/images", c.baseURL, playlistID)
 	// data flow:
 	// img (reader) -> copy into base64 encoder (writer) -> pipe (write end)
 	// pipe (read end) -> request body
 	r, w := io.Pipe()
+	// stops the encoder if the request isn't sent
+	defer r.Close()
 	go func() {
 		enc := base64.NewEncoder(base64.StdEncoding, w)
 		_, err := io.Copy(enc, img)
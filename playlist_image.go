@@ -0,0 +1,123 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+)
+
+// MaxPlaylistImageSize is the maximum size in bytes of the base64 encoded
+// JPEG image accepted by SetPlaylistImage.
+const MaxPlaylistImageSize = 256 * 1024
+
+// playlistImageQualities are the JPEG qualities tried, in order, by
+// SetPlaylistImageFromImage before downscaling the image.
+var playlistImageQualities = []int{90, 80, 70, 60, 50}
+
+// minPlaylistImageSide is the smallest width or height
+// SetPlaylistImageFromImage downscales images to.
+const minPlaylistImageSide = 64
+
+// GetPlaylistCoverImage returns the cover images of a playlist.
+// The URLs of the images are temporary, and expire in less than a day.
+func (c *Client) GetPlaylistCoverImage(ctx context.Context, playlistID ID) ([]Image, error) {
+	spotifyURL := fmt.Sprintf("%splaylists/%s/images", c.baseURL, playlistID)
+
+	var images []Image
+	err := c.get(ctx, spotifyURL, &images)
+	if err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+// SetPlaylistImageFromImage is like SetPlaylistImage, but it encodes img as
+// a JPEG image no larger than MaxPlaylistImageSize once base64 encoded.
+// Transparent areas are made white.  If the image is too large even with
+// a lower quality, it is downscaled until it fits.
+func (c *Client) SetPlaylistImageFromImage(ctx context.Context, playlistID ID, img image.Image) error {
+	data, err := encodePlaylistImage(img)
+	if err != nil {
+		return err
+	}
+	return c.SetPlaylistImage(ctx, playlistID, bytes.NewReader(data))
+}
+
+// SetPlaylistImageFromReader is like SetPlaylistImageFromImage, but it decodes
+// the image from r first.  JPEG, PNG and GIF images are supported.
+func (c *Client) SetPlaylistImageFromReader(ctx context.Context, playlistID ID, r io.Reader) error {
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return fmt.Errorf("spotify: couldn't decode image: %w", err)
+	}
+	return c.SetPlaylistImageFromImage(ctx, playlistID, img)
+}
+
+// encodePlaylistImage encodes img as a JPEG image small enough
+// to be used as a playlist's cover.
+func encodePlaylistImage(img image.Image) ([]byte, error) {
+	b := img.Bounds()
+	if b.Empty() {
+		return nil, errors.New("spotify: image is empty")
+	}
+	// JPEG doesn't support transparency.
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Over)
+
+	var buf bytes.Buffer
+	for {
+		for _, quality := range playlistImageQualities {
+			buf.Reset()
+			if err := jpeg.Encode(&buf, rgba, &jpeg.Options{Quality: quality}); err != nil {
+				return nil, err
+			}
+			if base64.StdEncoding.EncodedLen(buf.Len()) <= MaxPlaylistImageSize {
+				return buf.Bytes(), nil
+			}
+		}
+		w, h := rgba.Bounds().Dx()*3/4, rgba.Bounds().Dy()*3/4
+		if w < minPlaylistImageSide || h < minPlaylistImageSide {
+			return nil, errors.New("spotify: couldn't make the image small enough")
+		}
+		rgba = downscale(rgba, w, h)
+	}
+}
+
+// downscale returns src resized to w x h, which must be smaller than its
+// size.  Every pixel is the average of the pixels of src it covers.
+func downscale(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					i := src.PixOffset(sx, sy)
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,110 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"testing"
+)
+
+// receivedImage returns a validator that decodes the image sent
+// to SetPlaylistImage into *img.
+func receivedImage(t *testing.T, img *image.Image) func(*http.Request) {
+	return func(req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(body) > MaxPlaylistImageSize {
+			t.Errorf("Expected at most %d bytes, got %d", MaxPlaylistImageSize, len(body))
+		}
+		data, err := base64.StdEncoding.DecodeString(string(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *img, err = jpeg.Decode(bytes.NewReader(data)); err != nil {
+			t.Errorf("Expected a JPEG image: %v", err)
+		}
+	}
+}
+
+func TestSetPlaylistImageFromImage(t *testing.T) {
+	// noise compresses badly, so the image has to be downscaled
+	src := image.NewRGBA(image.Rect(0, 0, 1000, 1000))
+	rand.New(rand.NewSource(1)).Read(src.Pix)
+	for i := 3; i < len(src.Pix); i += 4 {
+		src.Pix[i] = 0xff
+	}
+
+	var got image.Image
+	client, server := testClientString(http.StatusAccepted, "", receivedImage(t, &got))
+	defer server.Close()
+
+	if err := client.SetPlaylistImageFromImage(context.Background(), "playlist", src); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("Expected an image to be sent")
+	}
+	if b := got.Bounds(); b.Dx() >= 1000 || b.Dx() != b.Dy() {
+		t.Errorf("Expected a smaller square image, got %v", b)
+	}
+}
+
+func TestSetPlaylistImageFromReader(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	for x := 0; x < 50; x++ {
+		for y := 0; y < 100; y++ {
+			src.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	var got image.Image
+	client, server := testClientString(http.StatusAccepted, "", receivedImage(t, &got))
+	defer server.Close()
+
+	if err := client.SetPlaylistImageFromReader(context.Background(), "playlist", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil || got.Bounds().Dx() != 100 {
+		t.Fatalf("Expected the image to keep its size, got %v", got)
+	}
+	if r, g, b, _ := got.At(10, 50).RGBA(); r < 0xf000 || g > 0x1000 || b > 0x1000 {
+		t.Errorf("Expected red, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+	if r, g, b, _ := got.At(90, 50).RGBA(); r < 0xf000 || g < 0xf000 || b < 0xf000 {
+		t.Errorf("Expected transparent pixels to be white, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+
+	if err := client.SetPlaylistImageFromReader(context.Background(), "playlist", bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Error("Expected an error for data that isn't an image")
+	}
+}
+
+func TestGetPlaylistCoverImage(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `[{"height": 640, "url": "https://mosaic.scdn.co/640/cover", "width": 640}]`, func(req *http.Request) {
+		if req.URL.Path != "/playlists/playlist/images" {
+			t.Errorf("Unexpected path %s", req.URL.Path)
+		}
+	})
+	defer server.Close()
+
+	images, err := client.GetPlaylistCoverImage(context.Background(), "playlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || images[0].Width != 640 || images[0].URL != "https://mosaic.scdn.co/640/cover" {
+		t.Errorf("Unexpected images %+v", images)
+	}
+}
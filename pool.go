@@ -0,0 +1,220 @@
+package spotify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultIdleTimeout is how long a ClientPool keeps a client that isn't
+// used, unless WithIdleTimeout is used.
+const defaultIdleTimeout = 30 * time.Minute
+
+// ClientFactory returns the HTTP client used to send requests on behalf of
+// a user.  The client is expected to attach the user's token to requests,
+// and to refresh it when needed, like the clients of spotifyauth do.
+// spotifyauth's Authenticator.ClientsFromStore returns a ClientFactory
+// creating clients from the tokens of a TokenStore.
+type ClientFactory func(userID string) (*http.Client, error)
+
+// InvalidGrantError is returned by the clients of a ClientPool when the token
+// of their user can't be refreshed any more, because the user revoked the
+// application's access or the refresh token expired.  The user needs to
+// authorize the application again.
+type InvalidGrantError struct {
+	// UserID identifies the user whose token is invalid.
+	UserID string
+	// Err is the error returned by the token endpoint.
+	Err *oauth2.RetrieveError
+}
+
+func (e *InvalidGrantError) Error() string {
+	return fmt.Sprintf("spotify: the token of user %q is no longer valid: %v", e.UserID, e.Err)
+}
+
+func (e *InvalidGrantError) Unwrap() error {
+	return e.Err
+}
+
+// ClientPool creates and caches the clients of many users.  Each user's client
+// is created the first time it is needed, and is then shared by all the
+// callers asking for it, so that the user's token is only refreshed once at
+// a time.  Clients that haven't been asked for in a while are discarded.
+//
+// ClientPool is safe for concurrent use.
+type ClientPool struct {
+	factory        ClientFactory
+	opts           []ClientOption
+	idleTimeout    time.Duration
+	onInvalidGrant func(userID string, err *InvalidGrantError)
+	now            func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+}
+
+type pooledClient struct {
+	// ready is closed once client and err are set.
+	ready    chan struct{}
+	client   *Client
+	err      error
+	lastUsed time.Time
+}
+
+// PoolOption configures a ClientPool.
+type PoolOption func(pool *ClientPool)
+
+// WithClientOptions sets the options used to create the clients of a ClientPool,
+// such as WithRetryPolicy or WithRateLimit.  WithHTTPClient is overridden.
+func WithClientOptions(opts ...ClientOption) PoolOption {
+	return func(pool *ClientPool) {
+		pool.opts = append(pool.opts, opts...)
+	}
+}
+
+// WithIdleTimeout sets how long a ClientPool keeps a client after it was last
+// returned by Get.  Zero keeps clients until they are removed.  Defaults to 30
+// minutes.
+func WithIdleTimeout(d time.Duration) PoolOption {
+	return func(pool *ClientPool) {
+		pool.idleTimeout = d
+	}
+}
+
+// WithInvalidGrantHandler sets a function called when the token of a user
+// can't be refreshed any more, for example to ask the user to authorize the
+// application again.  The user's client is removed from the pool before fn
+// is called, so that the next call to Get creates a new one.
+func WithInvalidGrantHandler(fn func(userID string, err *InvalidGrantError)) PoolOption {
+	return func(pool *ClientPool) {
+		pool.onInvalidGrant = fn
+	}
+}
+
+// NewClientPool returns a ClientPool that creates the HTTP clients of its
+// users with factory.
+func NewClientPool(factory ClientFactory, opts ...PoolOption) *ClientPool {
+	p := &ClientPool{
+		factory:     factory,
+		idleTimeout: defaultIdleTimeout,
+		now:         time.Now,
+		clients:     make(map[string]*pooledClient),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Get returns the client of the given user, creating it if needed.
+// Concurrent calls for the same user wait for the client to be created once.
+// If creating the client fails, the error is returned and the next call
+// tries again.
+func (p *ClientPool) Get(userID string) (*Client, error) {
+	p.mu.Lock()
+	now := p.now()
+	p.evictIdle(now)
+	pc, ok := p.clients[userID]
+	if ok {
+		pc.lastUsed = now
+		p.mu.Unlock()
+		<-pc.ready
+		return pc.client, pc.err
+	}
+	pc = &pooledClient{ready: make(chan struct{}), lastUsed: now}
+	p.clients[userID] = pc
+	p.mu.Unlock()
+
+	pc.client, pc.err = p.newClient(userID, pc)
+	if pc.err != nil {
+		p.remove(userID, pc)
+	}
+	close(pc.ready)
+	return pc.client, pc.err
+}
+
+func (p *ClientPool) newClient(userID string, pc *pooledClient) (*Client, error) {
+	httpClient, err := p.factory(userID)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]ClientOption{WithMiddleware(p.invalidGrantMiddleware(userID, pc))}, p.opts...)
+	opts = append(opts, WithHTTPClient(httpClient))
+	return New(opts...), nil
+}
+
+// Remove discards the client of the given user, if any.
+func (p *ClientPool) Remove(userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, userID)
+}
+
+// remove discards pc if it is still the client of the given user.
+func (p *ClientPool) remove(userID string, pc *pooledClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients[userID] == pc {
+		delete(p.clients, userID)
+	}
+}
+
+// Len returns the number of clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.clients)
+}
+
+// evictIdle discards the clients that haven't been used since the idle timeout.
+// p.mu must be held.
+func (p *ClientPool) evictIdle(now time.Time) {
+	if p.idleTimeout <= 0 {
+		return
+	}
+	for userID, pc := range p.clients {
+		if now.Sub(pc.lastUsed) > p.idleTimeout {
+			delete(p.clients, userID)
+		}
+	}
+}
+
+// invalidGrantMiddleware turns the errors caused by the token of the given
+// user being revoked into InvalidGrantErrors, and discards pc, the user's client.
+func (p *ClientPool) invalidGrantMiddleware(userID string, pc *pooledClient) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err == nil || !isInvalidGrant(err) {
+				return resp, err
+			}
+			var retrieveErr *oauth2.RetrieveError
+			errors.As(err, &retrieveErr)
+			ige := &InvalidGrantError{UserID: userID, Err: retrieveErr}
+
+			p.remove(userID, pc)
+			if p.onInvalidGrant != nil {
+				p.onInvalidGrant(userID, ige)
+			}
+			return nil, ige
+		})
+	}
+}
+
+// isInvalidGrant reports whether err was caused by the token endpoint
+// refusing to refresh a token with an invalid_grant error.
+func isInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+	var body struct {
+		Error string `json:"error"`
+	}
+	return json.Unmarshal(retrieveErr.Body, &body) == nil && body.Error == "invalid_grant"
+}
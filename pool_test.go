@@ -0,0 +1,154 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenSourceFunc is an adapter to allow the use of ordinary functions as token sources.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestClientPoolCreatesClientsOnce(t *testing.T) {
+	var created int32
+	pool := NewClientPool(func(userID string) (*http.Client, error) {
+		atomic.AddInt32(&created, 1)
+		time.Sleep(10 * time.Millisecond)
+		return http.DefaultClient, nil
+	})
+
+	clients := make([]*Client, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Get("alice")
+			if err != nil {
+				t.Error(err)
+			}
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+
+	if created != 1 {
+		t.Errorf("Expected the client to be created once, got %d", created)
+	}
+	for _, c := range clients[1:] {
+		if c != clients[0] {
+			t.Error("Expected every caller to get the same client")
+		}
+	}
+	if _, err := pool.Get("bob"); err != nil {
+		t.Fatal(err)
+	}
+	if created != 2 || pool.Len() != 2 {
+		t.Errorf("Expected 2 clients, got %d created and %d pooled", created, pool.Len())
+	}
+}
+
+func TestClientPoolFactoryError(t *testing.T) {
+	errNoToken := errors.New("no token")
+	fail := true
+	pool := NewClientPool(func(userID string) (*http.Client, error) {
+		if fail {
+			return nil, errNoToken
+		}
+		return http.DefaultClient, nil
+	})
+
+	if _, err := pool.Get("alice"); !errors.Is(err, errNoToken) {
+		t.Errorf("Expected the factory's error, got %v", err)
+	}
+	if pool.Len() != 0 {
+		t.Errorf("Expected failed clients not to be pooled")
+	}
+	fail = false
+	if _, err := pool.Get("alice"); err != nil {
+		t.Errorf("Expected the client to be created on the next call, got %v", err)
+	}
+}
+
+func TestClientPoolEvictsIdleClients(t *testing.T) {
+	now := time.Now()
+	pool := NewClientPool(func(userID string) (*http.Client, error) {
+		return http.DefaultClient, nil
+	}, WithIdleTimeout(time.Minute))
+	pool.now = func() time.Time { return now }
+
+	alice, _ := pool.Get("alice")
+	now = now.Add(45 * time.Second)
+	_, _ = pool.Get("bob")
+	now = now.Add(30 * time.Second)
+
+	if c, _ := pool.Get("bob"); pool.Len() != 1 {
+		t.Errorf("Expected alice's client to be evicted, got %d clients", pool.Len())
+	} else if c == alice {
+		t.Error("Expected bob to get their own client")
+	}
+	if c, _ := pool.Get("alice"); c == alice {
+		t.Error("Expected a new client to be created after eviction")
+	}
+}
+
+func TestClientPoolInvalidGrant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "alice"}`))
+	}))
+	defer server.Close()
+
+	revoked := &oauth2.RetrieveError{
+		Response: &http.Response{Status: "400 Bad Request", StatusCode: http.StatusBadRequest},
+		Body:     []byte(`{"error":"invalid_grant","error_description":"Refresh token revoked"}`),
+	}
+	var created int
+	pool := NewClientPool(func(userID string) (*http.Client, error) {
+		created++
+		return oauth2.NewClient(context.Background(), tokenSourceFunc(func() (*oauth2.Token, error) {
+			if created == 1 {
+				return nil, revoked
+			}
+			return &oauth2.Token{AccessToken: "access"}, nil
+		})), nil
+	},
+		WithClientOptions(WithBaseURL(server.URL+"/")),
+		WithInvalidGrantHandler(func(userID string, err *InvalidGrantError) {
+			if userID != "alice" || err.UserID != "alice" || err.Err != revoked {
+				t.Errorf("Unexpected invalid grant for %q: %v", userID, err)
+			}
+		}),
+	)
+
+	client, err := pool.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.CurrentUser(context.Background())
+	var ige *InvalidGrantError
+	if !errors.As(err, &ige) {
+		t.Fatalf("Expected an InvalidGrantError, got %v", err)
+	}
+	if pool.Len() != 0 {
+		t.Error("Expected the client to be removed from the pool")
+	}
+
+	client, err = pool.Get("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user, err := client.CurrentUser(context.Background()); err != nil || user.ID != "alice" {
+		t.Errorf("Expected the new client to work, got %v, %v", user, err)
+	}
+}
@@ -0,0 +1,112 @@
+package spotify
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit configures the Spotify API client to send at most requestsPerSecond
+// requests per second on average, allowing bursts of up to burst requests.  The limit
+// is shared by every goroutine using the client.  When the server answers with a
+// 429 and a Retry-After header, no further requests are sent by the client until
+// that delay has passed.  A non-positive rate disables the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(client *Client) {
+		if requestsPerSecond <= 0 {
+			client.limiter = nil
+			return
+		}
+		client.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// rateLimiter is a token bucket.  Tokens are added at a constant rate up to
+// the size of the bucket, and every request consumes one token.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	// last is the time tokens were last added to the bucket.
+	last time.Time
+	// pausedUntil is set when the server asks us to slow down.
+	// The bucket does not refill before it.
+	pausedUntil time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket and returns how long
+// the caller has to wait before it may use it.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := now
+	if l.pausedUntil.After(start) {
+		start = l.pausedUntil
+	}
+	if start.After(l.last) {
+		l.tokens += start.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = start
+	}
+	l.tokens--
+
+	wait := start.Sub(now)
+	if l.tokens < 0 {
+		wait += time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	return wait
+}
+
+// cancel returns a token that was reserved but never used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// wait blocks until the caller may send a request, or until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	if err := sleep(ctx, d); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// backoff empties the bucket and stops it from refilling for d.
+func (l *rateLimiter) backoff(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	// Tokens are only credited from the end of the pause.
+	if l.pausedUntil.After(l.last) {
+		l.last = l.pausedUntil
+	}
+	if l.tokens > 0 {
+		l.tokens = 0
+	}
+}
@@ -0,0 +1,65 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := newRateLimiter(10, 2)
+	now := l.last
+
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reservation %d: expected to wait %s, got %s", i, want, got)
+		}
+	}
+
+	// After a second the bucket is full again, but no fuller than its burst size.
+	now = now.Add(time.Second)
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond} {
+		if got := l.reserve(now); got != want {
+			t.Errorf("reservation %d after refill: expected to wait %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestRateLimiterBackoffDoesNotRefill(t *testing.T) {
+	l := newRateLimiter(10, 5)
+	l.backoff(time.Second)
+	end := l.pausedUntil
+
+	// The bucket is still empty when the pause ends.
+	for i, want := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		if got := l.reserve(end); got != want {
+			t.Errorf("reservation %d: expected to wait %s, got %s", i, want, got)
+		}
+	}
+}
+
+func TestRateLimiterBacksOffOnRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL+"/"), WithRateLimit(100, 10))
+	if _, err := client.NewReleases(context.Background()); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	// Every other request sharing the client must now wait for the server's delay.
+	if d := client.limiter.reserve(time.Now()); d < 29*time.Second {
+		t.Errorf("Expected the limiter to pause for about 30s, got %s", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.NewReleases(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
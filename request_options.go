@@ -3,6 +3,7 @@ package spotify
 import (
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 type RequestOption func(*requestOptions)
@@ -120,3 +121,12 @@ func processOptions(options ...RequestOption) requestOptions {
 
 	return o
 }
+
+// AdditionalTypes sets the types of items, besides tracks, that the caller
+// supports, such as "episode".  The player reports other items as nothing
+// playing unless they are listed.
+func AdditionalTypes(types ...string) RequestOption {
+	return func(o *requestOptions) {
+		o.urlParams.Set("additional_types", strings.Join(types, ","))
+	}
+}
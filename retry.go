@@ -0,0 +1,259 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how the client retries requests that fail with a
+// transient error.  Zero values are replaced with sensible defaults when
+// the policy is passed to WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt.  Zero means 5; a negative value
+	// retries without limit.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.  Each following
+	// retry doubles the delay, up to MaxDelay.  Defaults to one second.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.  It does not apply
+	// to delays requested by the server with a Retry-After header.
+	// Defaults to 30 seconds.
+	MaxDelay time.Duration
+	// Jitter is the fraction, between 0 and 1, by which each computed
+	// delay is randomly shortened or lengthened.
+	Jitter float64
+	// Retryable reports whether req, which produced resp or err, should
+	// be sent again.  Exactly one of resp and err is non-nil.  Requests
+	// that aren't idempotent, such as the POST sent by AddTracksToPlaylist,
+	// may have been applied by the server even though they failed, so
+	// retrying them can repeat their effect.
+	// Defaults to DefaultRetryable, which only retries them when they
+	// can't have reached the server.
+	Retryable func(req *http.Request, resp *http.Response, err error) bool
+}
+
+// legacyRetryPolicy mimics the behaviour of WithRetry(true) for the requests
+// sent with execute: rate limited (429) and accepted but unfinished (202)
+// requests are retried forever, waiting as long as the server asks.
+var legacyRetryPolicy = RetryPolicy{
+	MaxAttempts: -1,
+	BaseDelay:   defaultRetryDuration,
+	MaxDelay:    defaultRetryDuration,
+	Retryable: func(req *http.Request, resp *http.Response, err error) bool {
+		return err == nil && shouldRetry(resp.StatusCode)
+	},
+}
+
+// legacyReadRetryPolicy mimics the behaviour of WithRetry(true) for the
+// requests sent with get, which are only retried when rate limited.
+var legacyReadRetryPolicy = RetryPolicy{
+	MaxAttempts: -1,
+	BaseDelay:   defaultRetryDuration,
+	MaxDelay:    defaultRetryDuration,
+	Retryable: func(req *http.Request, resp *http.Response, err error) bool {
+		return err == nil && resp.StatusCode == rateLimitExceededStatusCode
+	},
+}
+
+// WithRetryPolicy configures the Spotify API client to retry requests as described
+// by p.  Waiting between attempts is aborted as soon as the request's context is done.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 5
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = time.Second
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = 30 * time.Second
+	}
+	if p.Retryable == nil {
+		p.Retryable = DefaultRetryable
+	}
+	return func(client *Client) {
+		client.retry = &p
+	}
+}
+
+// DefaultRetryable reports whether resp or err is worth retrying: the server
+// is rate limiting us (429), is temporarily unavailable (502, 503, 504) or
+// the connection failed in a way that is likely to be transient.
+//
+// Requests that aren't idempotent, such as POST requests, are only retried
+// when the server rejected them before doing anything: when it is rate
+// limiting us, or when the connection was refused.
+func DefaultRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if !isIdempotent(req.Method) {
+		if err != nil {
+			return errors.Is(err, syscall.ECONNREFUSED)
+		}
+		return resp.StatusCode == http.StatusTooManyRequests
+	}
+	if err != nil {
+		return isTransientError(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isIdempotent reports whether sending a request with the given method
+// several times has the same effect as sending it once.
+func isIdempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// retryPolicy returns the policy the client should follow, or nil if
+// requests must not be retried.  read is true for the requests sent by get.
+func (c *Client) retryPolicy(read bool) *RetryPolicy {
+	if c.retry != nil {
+		return c.retry
+	}
+	if c.autoRetry && read {
+		return &legacyReadRetryPolicy
+	}
+	if c.autoRetry {
+		return &legacyRetryPolicy
+	}
+	return nil
+}
+
+// delay returns how long to wait before sending attempt+1.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+	d := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// do sends req to the Web API, through the client's middleware and rate
+// limiter, retrying it according to the client's retry policy.  read is true
+// for the requests sent by get.  Responses whose status is listed in
+// needsStatus are never retried.
+func (c *Client) do(req *http.Request, read bool, needsStatus ...int) (*http.Response, error) {
+	return c.doWith(c.doer(), c.limiter, req, read, needsStatus...)
+}
+
+// doWith sends req with doer, retrying it according to the client's retry
+// policy.  limiter may be nil, for requests that aren't sent to the Web API.
+func (c *Client) doWith(doer Doer, limiter *rateLimiter, req *http.Request, read bool, needsStatus ...int) (*http.Response, error) {
+	policy := c.retryPolicy(read)
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			if err := limiter.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := doer.Do(req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt)))
+		if limiter != nil && resp != nil && resp.StatusCode == rateLimitExceededStatusCode {
+			if d, ok := retryAfter(resp); ok {
+				limiter.backoff(d)
+			}
+		}
+		if policy == nil ||
+			(policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts) ||
+			(resp != nil && !isFailure(resp.StatusCode, needsStatus)) ||
+			!policy.Retryable(req, resp, err) {
+			return resp, err
+		}
+		// A body that has already been sent can only be replayed if the
+		// request knows how to produce a fresh copy of it.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := policy.delay(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// sleep pauses for d, returning early with the context's error if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryAfter parses the Retry-After header of resp, if there is one.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	raw := resp.Header.Get("Retry-After")
+	if raw == "" {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func retryDuration(resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+	return defaultRetryDuration
+}
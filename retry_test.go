@@ -0,0 +1,172 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyGivesUp(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = io.WriteString(w, `{ "error": { "message": "unavailable", "status": 503 } }`)
+	}))
+	defer server.Close()
+
+	client := New(
+		WithBaseURL(server.URL+"/"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	_, err := client.GetArtist(context.Background(), "0TnOYISbd1XYRBk9myaseg")
+	serr, ok := err.(Error)
+	if !ok {
+		t.Fatalf("Expected spotify error, got %v", err)
+	}
+	if serr.Status != http.StatusServiceUnavailable {
+		t.Errorf("Expected HTTP 503, got %d", serr.Status)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryPolicyReplaysBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := New(
+		WithBaseURL(server.URL+"/"),
+		WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+	if err := client.TransferPlayback(context.Background(), "abc", true); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("Expected the same body to be sent twice, got %q", bodies)
+	}
+}
+
+func TestRetryPolicyHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL+"/"), WithRetry(true))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.NewReleases(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Retry did not stop when the context expired (took %s)", elapsed)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if got := p.delay(attempt+1, nil); got != want {
+			t.Errorf("attempt %d: expected %s, got %s", attempt+1, want, got)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"12"}}}
+	if got := p.delay(1, resp); got != 12*time.Second {
+		t.Errorf("Expected Retry-After to be honoured, got %s", got)
+	}
+}
+
+func TestLegacyRetryAccepted(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL+"/"), WithRetry(true))
+
+	// Reads were never retried on 202.
+	if _, err := client.GetArtist(context.Background(), "0TnOYISbd1XYRBk9myaseg"); err == nil {
+		t.Error("Expected GET to fail with HTTP 202")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected GET to be sent once, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	if err := client.Pause(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected PUT to be retried after HTTP 202, got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	var statuses []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		w.WriteHeader(status)
+		if status == http.StatusCreated {
+			_, _ = io.WriteString(w, `{"snapshot_id": "abc"}`)
+		}
+	}))
+	defer server.Close()
+	client := New(
+		WithBaseURL(server.URL+"/"),
+		WithRetryPolicy(RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+
+	// The server may have added the tracks before failing.
+	statuses = []int{http.StatusServiceUnavailable, http.StatusCreated}
+	if _, err := client.AddTracksToPlaylist(context.Background(), "playlist", "track"); err == nil {
+		t.Error("Expected POST not to be retried after HTTP 503")
+	}
+	if len(statuses) != 1 {
+		t.Errorf("Expected POST to be sent once, got %d attempts", 2-len(statuses))
+	}
+
+	statuses = []int{http.StatusTooManyRequests, http.StatusCreated}
+	if _, err := client.AddTracksToPlaylist(context.Background(), "playlist", "track"); err != nil {
+		t.Errorf("Expected POST to be retried after HTTP 429, got %v", err)
+	}
+}
+
+func TestDefaultRetryableConnectionRefused(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/", nil)
+	err := &url.Error{Op: "Post", URL: req.URL.String(), Err: syscall.ECONNREFUSED}
+	if !DefaultRetryable(req, nil, err) {
+		t.Error("Expected a refused POST to be retried")
+	}
+	if DefaultRetryable(req, nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: io.EOF}) {
+		t.Error("Expected a POST whose connection was closed not to be retried")
+	}
+}
@@ -0,0 +1,273 @@
+package spotify
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/conradludgate/spotify/v2/auth/scope"
+)
+
+// endpointScopes lists the scopes an endpoint of the Web API requires.
+type endpointScopes struct {
+	method string
+	// path is relative to the base URL.  A "*" segment matches any segment.
+	path string
+	// operations are the methods of Client that use the endpoint.
+	operations []string
+	// all are the scopes the endpoint always requires.
+	all []string
+	// any are scopes the endpoint requires one of, depending on the resource.
+	any []string
+	// optional are scopes the endpoint works without, but that make it
+	// return more, such as private playlists.  They are never checked.
+	optional []string
+}
+
+var (
+	modifyPlaylist = []string{scope.PlaylistModifyPublic, scope.PlaylistModifyPrivate}
+	libraryRead    = []string{scope.UserLibraryRead}
+	libraryModify  = []string{scope.UserLibraryModify}
+	followRead     = []string{scope.UserFollowRead}
+	followModify   = []string{scope.UserFollowModify}
+	playbackState  = []string{scope.UserReadPlaybackState}
+	modifyPlayback = []string{scope.UserModifyPlaybackState}
+)
+
+// scopeTable lists the endpoints that require scopes.
+// Endpoints that don't require any are omitted.
+var scopeTable = []endpointScopes{
+	{method: "GET", path: "me/tracks", operations: []string{"CurrentUsersTracks"}, all: libraryRead},
+	{method: "GET", path: "me/tracks/contains", operations: []string{"UserHasTracks", "UserHasTracksAll"}, all: libraryRead},
+	{method: "PUT", path: "me/tracks", operations: []string{"AddTracksToLibrary", "AddTracksToLibraryAll"}, all: libraryModify},
+	{method: "DELETE", path: "me/tracks", operations: []string{"RemoveTracksFromLibrary", "RemoveTracksFromLibraryAll"}, all: libraryModify},
+	{method: "GET", path: "me/albums", operations: []string{"CurrentUsersAlbums"}, all: libraryRead},
+	{method: "GET", path: "me/albums/contains", operations: []string{"UserHasAlbums", "UserHasAlbumsAll"}, all: libraryRead},
+	{method: "PUT", path: "me/albums", operations: []string{"AddAlbumsToLibrary", "AddAlbumsToLibraryAll"}, all: libraryModify},
+	{method: "DELETE", path: "me/albums", operations: []string{"RemoveAlbumsFromLibrary", "RemoveAlbumsFromLibraryAll"}, all: libraryModify},
+	{method: "GET", path: "me/shows", operations: []string{"CurrentUsersShows"}, all: libraryRead},
+	{method: "GET", path: "me/shows/contains", operations: []string{"UserHasShows", "UserHasShowsAll"}, all: libraryRead},
+	{method: "PUT", path: "me/shows", operations: []string{"AddShowsToLibrary", "AddShowsToLibraryAll"}, all: libraryModify},
+	{method: "DELETE", path: "me/shows", operations: []string{"RemoveShowsFromLibrary", "RemoveShowsFromLibraryAll"}, all: libraryModify},
+	{method: "GET", path: "me/audiobooks", operations: []string{"CurrentUsersAudiobooks"}, all: libraryRead},
+	{method: "GET", path: "me/audiobooks/contains", operations: []string{"UserHasAudiobooks", "UserHasAudiobooksAll"}, all: libraryRead},
+	{method: "PUT", path: "me/audiobooks", operations: []string{"AddAudiobooksToLibrary", "AddAudiobooksToLibraryAll"}, all: libraryModify},
+	{method: "DELETE", path: "me/audiobooks", operations: []string{"RemoveAudiobooksFromLibrary", "RemoveAudiobooksFromLibraryAll"}, all: libraryModify},
+	{method: "GET", path: "me/episodes", operations: []string{"CurrentUsersEpisodes"}, all: []string{scope.UserLibraryRead, scope.UserReadPlaybackPosition}},
+	{method: "GET", path: "me/episodes/contains", operations: []string{"UserHasEpisodes", "UserHasEpisodesAll"}, all: libraryRead},
+	{method: "PUT", path: "me/episodes", operations: []string{"AddEpisodesToLibrary", "AddEpisodesToLibraryAll"}, all: libraryModify},
+	{method: "DELETE", path: "me/episodes", operations: []string{"RemoveEpisodesFromLibrary", "RemoveEpisodesFromLibraryAll"}, all: libraryModify},
+
+	{method: "GET", path: "me/following", operations: []string{"CurrentUsersFollowedArtists"}, all: followRead},
+	{method: "GET", path: "me/following/contains", operations: []string{"CurrentUserFollows"}, all: followRead},
+	{method: "PUT", path: "me/following", operations: []string{"FollowUser", "FollowArtist"}, all: followModify},
+	{method: "DELETE", path: "me/following", operations: []string{"UnfollowUser", "UnfollowArtist"}, all: followModify},
+	{method: "GET", path: "me/top/*", operations: []string{"CurrentUsersTopArtists", "CurrentUsersTopTracks"}, all: []string{scope.UserTopRead}},
+
+	{method: "GET", path: "me/playlists", operations: []string{"CurrentUsersPlaylists"}, optional: []string{scope.PlaylistReadPrivate}},
+	{method: "PUT", path: "playlists/*/followers", operations: []string{"FollowPlaylist"}, any: modifyPlaylist},
+	{method: "DELETE", path: "playlists/*/followers", operations: []string{"UnfollowPlaylist"}, any: modifyPlaylist},
+	{method: "POST", path: "users/*/playlists", operations: []string{"CreatePlaylistForUser"}, any: modifyPlaylist},
+	{method: "PUT", path: "playlists/*", operations: []string{
+		"ChangePlaylistName", "ChangePlaylistAccess", "ChangePlaylistDescription",
+		"ChangePlaylistNameAndAccess", "ChangePlaylistNameAccessAndDescription",
+	}, any: modifyPlaylist},
+	{method: "POST", path: "playlists/*/tracks", operations: []string{"AddTracksToPlaylist", "AddTracksToPlaylistAll"}, any: modifyPlaylist},
+	{method: "DELETE", path: "playlists/*/tracks", operations: []string{"RemoveTracksFromPlaylist", "RemoveTracksFromPlaylistOpt"}, any: modifyPlaylist},
+	{method: "PUT", path: "playlists/*/tracks", operations: []string{"ReplacePlaylistTracks", "ReorderPlaylistTracks"}, any: modifyPlaylist},
+	{method: "PUT", path: "playlists/*/images", operations: []string{"SetPlaylistImage", "SetPlaylistImageFromImage", "SetPlaylistImageFromReader"}, all: []string{scope.ImageUpload}, any: modifyPlaylist},
+
+	{method: "GET", path: "me/player", operations: []string{"PlayerState"}, all: playbackState},
+	{method: "GET", path: "me/player/devices", operations: []string{"PlayerDevices"}, all: playbackState},
+	{method: "GET", path: "me/player/currently-playing", operations: []string{"PlayerCurrentlyPlaying"}, any: []string{scope.UserReadCurrentlyPlaying, scope.UserReadPlaybackState}},
+	{method: "GET", path: "me/player/recently-played", operations: []string{"PlayerRecentlyPlayed", "PlayerRecentlyPlayedOpt", "PlayerRecentlyPlayedPage"}, all: []string{scope.UserReadRecentlyPlayed}},
+	{method: "PUT", path: "me/player", operations: []string{"TransferPlayback"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/play", operations: []string{"Play", "PlayOpt"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/pause", operations: []string{"Pause", "PauseOpt"}, all: modifyPlayback},
+	{method: "POST", path: "me/player/queue", operations: []string{"QueueSong", "QueueSongOpt"}, all: modifyPlayback},
+	{method: "POST", path: "me/player/next", operations: []string{"Next", "NextOpt"}, all: modifyPlayback},
+	{method: "POST", path: "me/player/previous", operations: []string{"Previous", "PreviousOpt"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/seek", operations: []string{"Seek", "SeekOpt"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/repeat", operations: []string{"Repeat", "RepeatOpt"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/volume", operations: []string{"Volume", "VolumeOpt"}, all: modifyPlayback},
+	{method: "PUT", path: "me/player/shuffle", operations: []string{"Shuffle", "ShuffleOpt"}, all: modifyPlayback},
+}
+
+// MissingScopeError is returned, without sending the request, when a client
+// created with WithGrantedScopes is asked to call an endpoint that requires
+// scopes the user didn't grant.  It matches ErrForbidden.
+type MissingScopeError struct {
+	// Endpoint is the method and path of the request, such as "PUT me/following".
+	Endpoint string
+	// Missing are the scopes the endpoint requires that weren't granted.
+	Missing []string
+	// AnyOf, if set, are scopes the endpoint requires one of, none of which
+	// was granted.  Which one is needed depends on the resource, for example
+	// on whether a playlist is public.
+	AnyOf []string
+}
+
+func (e *MissingScopeError) Error() string {
+	var required []string
+	if len(e.Missing) > 0 {
+		required = append(required, strings.Join(e.Missing, ", "))
+	}
+	if len(e.AnyOf) > 0 {
+		required = append(required, "one of "+strings.Join(e.AnyOf, ", "))
+	}
+	return fmt.Sprintf("spotify: %s requires scopes that weren't granted: %s", e.Endpoint, strings.Join(required, "; "))
+}
+
+// Is reports whether target is ErrForbidden, which the request would have
+// failed with.
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// WithGrantedScopes tells the client which scopes the user granted, for
+// example with spotifyauth.GrantedScopes(token).  The client then returns a
+// MissingScopeError instead of sending requests that require other scopes.
+func WithGrantedScopes(scopes ...string) ClientOption {
+	return func(client *Client) {
+		client.grantedScopes = make(map[string]bool, len(scopes))
+		for _, s := range scopes {
+			client.grantedScopes[s] = true
+		}
+	}
+}
+
+// RequiredScopes returns the smallest set of scopes that allows calling all
+// the given operations, which are names of methods of Client such as
+// "FollowUser" or "PlayerState".  When an operation needs one of several
+// scopes depending on the resource, such as the public or private playlist
+// modification scopes, all of them are included.  So are the scopes that
+// operations work without but need to return everything, such as the scope
+// to read private playlists.  An error is returned for names that aren't
+// methods of Client sending requests.
+func RequiredScopes(operations ...string) ([]string, error) {
+	set := map[string]bool{}
+	for _, op := range operations {
+		if !knownOperation(op) {
+			return nil, fmt.Errorf("spotify: unknown operation %q", op)
+		}
+		for _, e := range scopeTable {
+			if !containsString(e.operations, op) {
+				continue
+			}
+			for _, s := range e.all {
+				set[s] = true
+			}
+			for _, s := range e.any {
+				set[s] = true
+			}
+			for _, s := range e.optional {
+				set[s] = true
+			}
+		}
+	}
+	scopes := make([]string, 0, len(set))
+	for s := range set {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+// knownOperation reports whether op is a method of Client that sends requests.
+func knownOperation(op string) bool {
+	for _, e := range scopeTable {
+		if containsString(e.operations, op) {
+			return true
+		}
+	}
+	return containsString(unscopedOperations, op)
+}
+
+// unscopedOperations are the methods of Client that send requests
+// which don't require any scope.
+var unscopedOperations = []string{
+	"GetAlbum", "GetAlbums", "GetAlbumsAll", "GetAlbumTracks",
+	"GetArtist", "GetArtists", "GetArtistsAll", "GetArtistsTopTracks", "GetRelatedArtists", "GetArtistAlbums",
+	"GetAudioAnalysis", "GetAudioFeatures", "GetAudioFeaturesAll",
+	"GetAudiobook", "GetAudiobooks", "GetAudiobooksAll", "GetAudiobookChapters", "GetChapter", "GetChapters", "GetChaptersAll",
+	"GetCategory", "GetCategoryPlaylists", "GetCategories",
+	"FeaturedPlaylists", "GetPlaylistsForUser", "GetPlaylist", "GetPlaylistTracks", "GetPlaylistCoverImage", "UserFollowsPlaylist",
+	"GetRecommendations", "GetAvailableGenreSeeds", "NewReleases", "Search",
+	"GetShow", "GetShows", "GetShowsAll", "GetShowEpisodes", "GetEpisode", "GetEpisodes", "GetEpisodesAll", "GetTrack", "GetTracks", "GetTracksAll",
+	"GetUsersPublicProfile", "CurrentUser",
+	// Images are fetched from the CDN, without the user's token.
+	"DownloadImage",
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// checkScopes returns a MissingScopeError if the client knows the granted
+// scopes and req requires others.
+func (c *Client) checkScopes(req *http.Request) error {
+	if c.grantedScopes == nil {
+		return nil
+	}
+	path := c.relativePath(req)
+	for _, e := range scopeTable {
+		if e.method != req.Method || !matchPath(e.path, path) {
+			continue
+		}
+		err := &MissingScopeError{Endpoint: e.method + " " + e.path}
+		for _, s := range e.all {
+			if !c.grantedScopes[s] {
+				err.Missing = append(err.Missing, s)
+			}
+		}
+		if len(e.any) > 0 {
+			granted := false
+			for _, s := range e.any {
+				granted = granted || c.grantedScopes[s]
+			}
+			if !granted {
+				err.AnyOf = e.any
+			}
+		}
+		if len(err.Missing) > 0 || len(err.AnyOf) > 0 {
+			return err
+		}
+		return nil
+	}
+	return nil
+}
+
+// relativePath returns the path of req relative to the base URL of the API.
+func (c *Client) relativePath(req *http.Request) string {
+	u := req.URL.String()
+	if strings.HasPrefix(u, c.baseURL) {
+		u = strings.TrimPrefix(u, c.baseURL)
+		if i := strings.IndexAny(u, "?#"); i >= 0 {
+			u = u[:i]
+		}
+		return strings.Trim(u, "/")
+	}
+	// Links returned by the API, such as the next page, use the default base URL.
+	return strings.TrimPrefix(strings.Trim(req.URL.Path, "/"), "v1/")
+}
+
+// matchPath reports whether path matches pattern, whose "*" segments match
+// any segment.
+func matchPath(pattern, path string) bool {
+	ps, segments := strings.Split(pattern, "/"), strings.Split(path, "/")
+	if len(ps) != len(segments) {
+		return false
+	}
+	for i := range ps {
+		if ps[i] != "*" && ps[i] != segments[i] {
+			return false
+		}
+	}
+	return true
+}
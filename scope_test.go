@@ -0,0 +1,137 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/conradludgate/spotify/v2/auth/scope"
+)
+
+func TestMissingScope(t *testing.T) {
+	sent := false
+	client, server := testClientString(http.StatusNoContent, "", func(*http.Request) { sent = true })
+	defer server.Close()
+	WithGrantedScopes(scope.UserFollowRead)(client)
+
+	err := client.FollowUser(context.Background(), "alice")
+	var mse *MissingScopeError
+	if !errors.As(err, &mse) {
+		t.Fatalf("Expected a MissingScopeError, got %v", err)
+	}
+	if sent {
+		t.Error("Expected the request not to be sent")
+	}
+	if mse.Endpoint != "PUT me/following" || !reflect.DeepEqual(mse.Missing, []string{scope.UserFollowModify}) {
+		t.Errorf("Unexpected error %+v", mse)
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Error("Expected the error to match ErrForbidden")
+	}
+
+	if _, err := client.CurrentUserFollows(context.Background(), "user", "alice"); errors.As(err, &mse) {
+		t.Errorf("Expected granted scopes to be accepted, got %v", err)
+	}
+	if !sent {
+		t.Error("Expected the request to be sent")
+	}
+}
+
+func TestMissingScopeAnyOf(t *testing.T) {
+	client, server := testClientString(http.StatusCreated, `{"snapshot_id": "snapshot"}`)
+	defer server.Close()
+	WithGrantedScopes(scope.PlaylistModifyPrivate)(client)
+
+	if _, err := client.AddTracksToPlaylist(context.Background(), "playlist", "track"); err != nil {
+		t.Errorf("Expected one of the playlist modification scopes to be enough, got %v", err)
+	}
+
+	err := client.SetPlaylistImage(context.Background(), "playlist", strings.NewReader("image"))
+	var mse *MissingScopeError
+	if !errors.As(err, &mse) || !reflect.DeepEqual(mse.Missing, []string{scope.ImageUpload}) || mse.AnyOf != nil {
+		t.Errorf("Expected the image upload scope to be missing, got %v", err)
+	}
+
+	WithGrantedScopes()(client)
+	_, err = client.AddTracksToPlaylist(context.Background(), "playlist", "track")
+	if !errors.As(err, &mse) || len(mse.AnyOf) != 2 || mse.Missing != nil {
+		t.Errorf("Expected either playlist modification scope to be required, got %v", err)
+	}
+}
+
+func TestOptionalScopesNotChecked(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"items": []}`)
+	defer server.Close()
+	WithGrantedScopes()(client)
+
+	if _, err := client.CurrentUsersPlaylists(context.Background()); err != nil {
+		t.Errorf("Expected public playlists to be readable without scopes, got %v", err)
+	}
+	scopes, err := RequiredScopes("CurrentUsersPlaylists")
+	if err != nil || !reflect.DeepEqual(scopes, []string{scope.PlaylistReadPrivate}) {
+		t.Errorf("Expected the private playlists scope to be suggested, got %v %v", scopes, err)
+	}
+}
+
+func TestScopesNotCheckedByDefault(t *testing.T) {
+	client, server := testClientString(http.StatusNoContent, "")
+	defer server.Close()
+	if err := client.FollowUser(context.Background(), "alice"); err != nil {
+		t.Errorf("Expected scopes not to be checked, got %v", err)
+	}
+}
+
+func TestScopesCheckedForPageLinks(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"items": []}`)
+	defer server.Close()
+	WithGrantedScopes()(client)
+
+	var page SavedTrackPage
+	err := client.get(context.Background(), "https://api.spotify.com/v1/me/tracks?offset=20", &page)
+	var mse *MissingScopeError
+	if !errors.As(err, &mse) || mse.Endpoint != "GET me/tracks" {
+		t.Errorf("Expected a MissingScopeError for GET me/tracks, got %v", err)
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	scopes, err := RequiredScopes("FollowUser", "FollowArtist", "PlayerState", "Play", "GetAlbum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		scope.UserFollowModify,
+		scope.UserModifyPlaybackState,
+		scope.UserReadPlaybackState,
+	}
+	if !reflect.DeepEqual(scopes, want) {
+		t.Errorf("Expected %v, got %v", want, scopes)
+	}
+
+	if scopes, err := RequiredScopes("GetTrack"); err != nil || len(scopes) != 0 {
+		t.Errorf("Expected no scope, got %v, %v", scopes, err)
+	}
+	if _, err := RequiredScopes("FolowUser"); err == nil {
+		t.Error("Expected an error for an unknown operation")
+	}
+}
+
+func TestScopeTableOperationsExist(t *testing.T) {
+	typ := reflect.TypeOf(&Client{})
+	check := func(op string) {
+		if _, ok := typ.MethodByName(op); !ok {
+			t.Errorf("%s is not a method of Client", op)
+		}
+	}
+	for _, e := range scopeTable {
+		for _, op := range e.operations {
+			check(op)
+		}
+	}
+	for _, op := range unscopedOperations {
+		check(op)
+	}
+}
@@ -21,10 +21,11 @@ type SearchType int
 // Search type values that can be passed to the Search function.  These are flags
 // that can be bitwise OR'd together to search for multiple types of content simultaneously.
 const (
-	SearchTypeAlbum    SearchType = 1 << iota
-	SearchTypeArtist              = 1 << iota
-	SearchTypePlaylist            = 1 << iota
-	SearchTypeTrack               = 1 << iota
+	SearchTypeAlbum     SearchType = 1 << iota
+	SearchTypeArtist               = 1 << iota
+	SearchTypePlaylist             = 1 << iota
+	SearchTypeTrack                = 1 << iota
+	SearchTypeAudiobook            = 1 << iota
 )
 
 func (st SearchType) encode() string {
@@ -41,6 +42,9 @@ func (st SearchType) encode() string {
 	if st&SearchTypeTrack != 0 {
 		types = append(types, "track")
 	}
+	if st&SearchTypeAudiobook != 0 {
+		types = append(types, "audiobook")
+	}
 	return strings.Join(types, ",")
 }
 
@@ -51,6 +55,8 @@ type SearchResult struct {
 	Albums    *SimpleAlbumPage    `json:"albums"`
 	Playlists *SimplePlaylistPage `json:"playlists"`
 	Tracks    *FullTrackPage      `json:"tracks"`
+	// Audiobooks are only available in some markets.
+	Audiobooks *SimpleAudiobookPage `json:"audiobooks"`
 }
 
 // Search gets Spotify catalog information about artists, albums, tracks,
@@ -122,10 +128,57 @@ func (c *Client) Search(ctx context.Context, query string, t SearchType, opts ..
 	if err != nil {
 		return nil, err
 	}
+	result.setWrappers()
 
 	return &result, err
 }
 
+// setWrappers records, for each page of s, the search result holding only
+// that page which the page's next and previous URLs return.
+func (s *SearchResult) setWrappers() {
+	if s.Artists != nil {
+		s.Artists.newWrapper = func() wrappedPage { return new(searchArtists) }
+	}
+	if s.Albums != nil {
+		s.Albums.newWrapper = func() wrappedPage { return new(searchAlbums) }
+	}
+	if s.Playlists != nil {
+		s.Playlists.newWrapper = func() wrappedPage { return new(searchPlaylists) }
+	}
+	if s.Tracks != nil {
+		s.Tracks.newWrapper = func() wrappedPage { return new(searchTracks) }
+	}
+	if s.Audiobooks != nil {
+		s.Audiobooks.newWrapper = func() wrappedPage { return new(searchAudiobooks) }
+	}
+}
+
+type searchArtists struct {
+	Artists FullArtistPage `json:"artists"`
+}
+
+type searchAlbums struct {
+	Albums SimpleAlbumPage `json:"albums"`
+}
+
+type searchPlaylists struct {
+	Playlists SimplePlaylistPage `json:"playlists"`
+}
+
+type searchTracks struct {
+	Tracks FullTrackPage `json:"tracks"`
+}
+
+type searchAudiobooks struct {
+	Audiobooks SimpleAudiobookPage `json:"audiobooks"`
+}
+
+func (s *searchArtists) unwrapPage() pageable    { return &s.Artists }
+func (s *searchAlbums) unwrapPage() pageable     { return &s.Albums }
+func (s *searchPlaylists) unwrapPage() pageable  { return &s.Playlists }
+func (s *searchTracks) unwrapPage() pageable     { return &s.Tracks }
+func (s *searchAudiobooks) unwrapPage() pageable { return &s.Audiobooks }
+
 // NextArtistResults loads the next page of artists into the specified search result.
 func (c *Client) NextArtistResults(ctx context.Context, s *SearchResult) error {
 	if s.Artists == nil || s.Artists.Next == "" {
@@ -189,3 +242,19 @@ func (c *Client) NextTrackResults(ctx context.Context, s *SearchResult) error {
 	}
 	return c.get(ctx, s.Tracks.Next, s)
 }
+
+// NextAudiobookResults loads the next page of audiobooks into the specified search result.
+func (c *Client) NextAudiobookResults(ctx context.Context, s *SearchResult) error {
+	if s.Audiobooks == nil || s.Audiobooks.Next == "" {
+		return ErrNoMorePages
+	}
+	return c.get(ctx, s.Audiobooks.Next, s)
+}
+
+// PreviousAudiobookResults loads the previous page of audiobooks into the specified search result.
+func (c *Client) PreviousAudiobookResults(ctx context.Context, s *SearchResult) error {
+	if s.Audiobooks == nil || s.Audiobooks.Previous == "" {
+		return ErrNoMorePages
+	}
+	return c.get(ctx, s.Audiobooks.Previous, s)
+}
@@ -78,6 +78,35 @@ func TestSearchPlaylistTrack(t *testing.T) {
 	}
 }
 
+func TestSearchAudiobooks(t *testing.T) {
+	client, server := testClientString(http.StatusOK, `{"audiobooks": {
+  "href": "https://api.spotify.com/v1/search?query=dune&type=audiobook&offset=0&limit=1",
+  "items": [{"id": "7iHfbu1YPACw6oZPAFJtqe", "name": "Dune", "authors": [{"name": "Frank Herbert"}], "type": "audiobook"}],
+  "limit": 1,
+  "next": "https://api.spotify.com/v1/search?query=dune&type=audiobook&offset=1&limit=1",
+  "offset": 0,
+  "previous": null,
+  "total": 12
+}}`, func(req *http.Request) {
+		if typ := req.URL.Query().Get("type"); typ != "track,audiobook" {
+			t.Errorf("Unexpected type %q", typ)
+		}
+	})
+	defer server.Close()
+
+	result, err := client.Search(context.Background(), "dune", SearchTypeTrack|SearchTypeAudiobook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Audiobooks == nil || len(result.Audiobooks.Audiobooks) != 1 {
+		t.Fatal("Didn't receive audiobook results")
+	}
+	a := result.Audiobooks.Audiobooks[0]
+	if a.Name != "Dune" || len(a.Authors) != 1 || a.Authors[0].Name != "Frank Herbert" {
+		t.Errorf("Invalid audiobook %+v", a)
+	}
+}
+
 func TestPrevNextSearchPageErrors(t *testing.T) {
 	client, server := testClientString(0, "")
 	defer server.Close()
@@ -86,36 +115,41 @@ func TestPrevNextSearchPageErrors(t *testing.T) {
 	// under either of these conditions:
 
 	//  1) there are no results (nil)
-	nilResults := &SearchResult{nil, nil, nil, nil}
+	nilResults := &SearchResult{nil, nil, nil, nil, nil}
 	if client.NextAlbumResults(context.Background(), nilResults) != ErrNoMorePages ||
 		client.NextArtistResults(context.Background(), nilResults) != ErrNoMorePages ||
 		client.NextPlaylistResults(context.Background(), nilResults) != ErrNoMorePages ||
-		client.NextTrackResults(context.Background(), nilResults) != ErrNoMorePages {
+		client.NextTrackResults(context.Background(), nilResults) != ErrNoMorePages ||
+		client.NextAudiobookResults(context.Background(), nilResults) != ErrNoMorePages {
 		t.Error("Next search result page should have failed for nil results")
 	}
 	if client.PreviousAlbumResults(context.Background(), nilResults) != ErrNoMorePages ||
 		client.PreviousArtistResults(context.Background(), nilResults) != ErrNoMorePages ||
 		client.PreviousPlaylistResults(context.Background(), nilResults) != ErrNoMorePages ||
-		client.PreviousTrackResults(context.Background(), nilResults) != ErrNoMorePages {
+		client.PreviousTrackResults(context.Background(), nilResults) != ErrNoMorePages ||
+		client.PreviousAudiobookResults(context.Background(), nilResults) != ErrNoMorePages {
 		t.Error("Previous search result page should have failed for nil results")
 	}
 	//  2) the prev/next URL is empty
 	emptyURL := &SearchResult{
-		Artists:   new(FullArtistPage),
-		Albums:    new(SimpleAlbumPage),
-		Playlists: new(SimplePlaylistPage),
-		Tracks:    new(FullTrackPage),
+		Artists:    new(FullArtistPage),
+		Albums:     new(SimpleAlbumPage),
+		Playlists:  new(SimplePlaylistPage),
+		Tracks:     new(FullTrackPage),
+		Audiobooks: new(SimpleAudiobookPage),
 	}
 	if client.NextAlbumResults(context.Background(), emptyURL) != ErrNoMorePages ||
 		client.NextArtistResults(context.Background(), emptyURL) != ErrNoMorePages ||
 		client.NextPlaylistResults(context.Background(), emptyURL) != ErrNoMorePages ||
-		client.NextTrackResults(context.Background(), emptyURL) != ErrNoMorePages {
+		client.NextTrackResults(context.Background(), emptyURL) != ErrNoMorePages ||
+		client.NextAudiobookResults(context.Background(), emptyURL) != ErrNoMorePages {
 		t.Error("Next search result page should have failed with empty URL")
 	}
 	if client.PreviousAlbumResults(context.Background(), emptyURL) != ErrNoMorePages ||
 		client.PreviousArtistResults(context.Background(), emptyURL) != ErrNoMorePages ||
 		client.PreviousPlaylistResults(context.Background(), emptyURL) != ErrNoMorePages ||
-		client.PreviousTrackResults(context.Background(), emptyURL) != ErrNoMorePages {
+		client.PreviousTrackResults(context.Background(), emptyURL) != ErrNoMorePages ||
+		client.PreviousAudiobookResults(context.Background(), emptyURL) != ErrNoMorePages {
 		t.Error("Previous search result page should have failed with empty URL")
 	}
 }
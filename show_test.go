@@ -40,3 +40,37 @@ func TestGetShowEpisodes(t *testing.T) {
 		t.Error("Invalid data", len(r.Episodes))
 	}
 }
+
+func TestGetShows(t *testing.T) {
+	c, s := testClientString(http.StatusOK, `{"shows": [
+  {"id": "5CfCWKI5pZ28U0uOzXkDHe", "name": "Without Fail", "publisher": "Gimlet", "type": "show"},
+  null
+]}`, func(req *http.Request) {
+		q := req.URL.Query()
+		if ids := q.Get("ids"); ids != "5CfCWKI5pZ28U0uOzXkDHe,5as3aKmN2k11yfDDDSrvaZ" {
+			t.Errorf("Unexpected ids %q", ids)
+		}
+		if m := q.Get("market"); m != "US" {
+			t.Errorf("Expected market US, got %q", m)
+		}
+	})
+	defer s.Close()
+
+	shows, err := c.GetShows(context.Background(), []ID{"5CfCWKI5pZ28U0uOzXkDHe", "5as3aKmN2k11yfDDDSrvaZ"}, Market("US"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shows) != 2 {
+		t.Fatal("Expected 2 shows, got", len(shows))
+	}
+	if shows[0] == nil || shows[0].Name != "Without Fail" {
+		t.Error("Invalid first show")
+	}
+	if shows[1] != nil {
+		t.Error("Expected the missing show to be nil")
+	}
+
+	if _, err := c.GetShows(context.Background(), make([]ID, 51)); err == nil {
+		t.Error("Expected an error for more than 50 IDs")
+	}
+}
@@ -45,7 +45,15 @@ type Client struct {
 	baseURL string
 
 	autoRetry      bool
+	retry          *RetryPolicy
+	limiter        *rateLimiter
+	middleware     []Middleware
+	concurrency    int
+	cache          Cache
 	acceptLanguage string
+	grantedScopes  map[string]bool
+	imageCache     *ImageCache
+	imageHTTP      *http.Client
 }
 
 type ClientOption func(client *Client)
@@ -58,9 +66,12 @@ func WithHTTPClient(c *http.Client) ClientOption {
 }
 
 // WithRetry configures the Spotify API client to automatically retry requests that fail due to ratelimiting.
+// Requests are retried until they succeed, waiting as long as the server asks between attempts.
+// Use WithRetryPolicy for finer control.
 func WithRetry(shouldRetry bool) ClientOption {
 	return func(client *Client) {
 		client.autoRetry = shouldRetry
+		client.retry = nil
 	}
 }
 
@@ -96,7 +107,8 @@ func New(opts ...ClientOption) *Client {
 }
 
 // URI identifies an artist, album, track, or category.  For example,
-// spotify:track:6rqhFgbbKwnb9MLmUQDhG6
+// spotify:track:6rqhFgbbKwnb9MLmUQDhG6.  Use NewURI to build one,
+// and ParseURI to parse one.
 type URI string
 
 // ID is a base-62 identifier for an artist, track, album, etc.
@@ -128,6 +140,8 @@ type Image struct {
 }
 
 // Download downloads the image and writes its data to the specified io.Writer.
+// Use Client.DownloadImage to download images with a context, retries and
+// a cache.
 func (i Image) Download(dst io.Writer) error {
 	resp, err := http.Get(i.URL)
 	if err != nil {
@@ -143,11 +157,23 @@ func (i Image) Download(dst io.Writer) error {
 }
 
 // Error represents an error returned by the Spotify Web API.
+// Use errors.Is with ErrUnauthorized, ErrNotFound, ErrNoActiveDevice and
+// the other sentinel errors of this package to find out what went wrong.
 type Error struct {
 	// A short description of the error.
 	Message string `json:"message"`
 	// The HTTP status code.
 	Status int `json:"status"`
+	// The reason the request failed, such as ReasonNoActiveDevice.
+	// Only set by the player endpoints.
+	Reason string `json:"reason"`
+	// How long the server asked us to wait before retrying
+	// a rate limited request.
+	RetryAfter time.Duration `json:"-"`
+	// The URL of the request that failed.
+	URL string `json:"-"`
+	// The raw body of the response.
+	Body string `json:"-"`
 }
 
 func (e Error) Error() string {
@@ -161,32 +187,50 @@ func (c *Client) decodeError(resp *http.Response) error {
 		return err
 	}
 
+	e := Error{
+		Status: resp.StatusCode,
+		Body:   string(responseBody),
+	}
+	if resp.Request != nil {
+		e.URL = resp.Request.URL.String()
+	}
+	if resp.StatusCode == rateLimitExceededStatusCode {
+		e.RetryAfter = retryDuration(resp)
+	}
+
 	if len(responseBody) == 0 {
-		return fmt.Errorf("spotify: HTTP %d: %s (body empty)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		e.Message = fmt.Sprintf("spotify: HTTP %d: %s (body empty)", resp.StatusCode, http.StatusText(resp.StatusCode))
+		return e
 	}
 
 	buf := bytes.NewBuffer(responseBody)
 
-	var e struct {
+	var body struct {
 		E Error `json:"error"`
 	}
-	err = json.NewDecoder(buf).Decode(&e)
+	err = json.NewDecoder(buf).Decode(&body)
 	if err != nil {
-		return fmt.Errorf("spotify: couldn't decode error: (%d) [%s]", len(responseBody), responseBody)
+		e.Message = fmt.Sprintf("spotify: couldn't decode error: (%d) [%s]", len(responseBody), responseBody)
+		return e
+	}
+	e.Message = body.E.Message
+	e.Reason = body.E.Reason
+	if body.E.Status != 0 {
+		e.Status = body.E.Status
 	}
 
-	if e.E.Message == "" {
+	if e.Message == "" {
 		// Some errors will result in there being a useful status-code but an
 		// empty message, which will confuse the user (who only has access to
 		// the message and not the code). An example of this is when we send
 		// some of the arguments directly in the HTTP query and the URL ends-up
 		// being too long.
 
-		e.E.Message = fmt.Sprintf("spotify: unexpected HTTP %d: %s (empty error)",
+		e.Message = fmt.Sprintf("spotify: unexpected HTTP %d: %s (empty error)",
 			resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	return e.E
+	return e
 }
 
 // shouldRetry determines whether the status code indicates that the
@@ -209,87 +253,95 @@ func isFailure(code int, validCodes []int) bool {
 // status codes that will be treated as success. Note that we allow all 200s
 // even if there are additional success codes that represent success.
 func (c *Client) execute(req *http.Request, result interface{}, needsStatus ...int) error {
+	if err := c.checkScopes(req); err != nil {
+		return err
+	}
 	if c.acceptLanguage != "" {
 		req.Header.Set("Accept-Language", c.acceptLanguage)
 	}
-	for {
-		resp, err := c.http.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
+	resp, err := c.do(req, false, needsStatus...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		if c.autoRetry && shouldRetry(resp.StatusCode) {
-			time.Sleep(retryDuration(resp))
-			continue
-		}
-		if resp.StatusCode == http.StatusNoContent {
-			return nil
-		}
-		if (resp.StatusCode >= 300 ||
-			resp.StatusCode < 200) &&
-			isFailure(resp.StatusCode, needsStatus) {
-			return c.decodeError(resp)
-		}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if (resp.StatusCode >= 300 ||
+		resp.StatusCode < 200) &&
+		isFailure(resp.StatusCode, needsStatus) {
+		return c.decodeError(resp)
+	}
 
-		if result != nil {
-			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-				return err
-			}
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return err
 		}
-		break
 	}
 	return nil
 }
 
-func retryDuration(resp *http.Response) time.Duration {
-	raw := resp.Header.Get("Retry-After")
-	if raw == "" {
-		return defaultRetryDuration
-	}
-	seconds, err := strconv.ParseInt(raw, 10, 32)
+func (c *Client) get(ctx context.Context, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return defaultRetryDuration
+		return err
+	}
+	if err := c.checkScopes(req); err != nil {
+		return err
+	}
+	if c.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
 	}
-	return time.Duration(seconds) * time.Second
-}
 
-func (c *Client) get(ctx context.Context, url string, result interface{}) error {
-	for {
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if c.acceptLanguage != "" {
-			req.Header.Set("Accept-Language", c.acceptLanguage)
-		}
-		if err != nil {
-			return err
+	var cached *CachedResponse
+	if c.cache != nil {
+		cached, _ = c.cache.Get(c.cacheKey(url))
+		if cached != nil && time.Now().Before(cached.Expires) {
+			return json.Unmarshal(cached.Body, result)
 		}
-		resp, err := c.http.Do(req)
-		if err != nil {
-			return err
+		if cached != nil && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
 		}
+	}
 
-		defer resp.Body.Close()
+	resp, err := c.do(req, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
 
-		if resp.StatusCode == rateLimitExceededStatusCode && c.autoRetry {
-			time.Sleep(retryDuration(resp))
-			continue
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if resp.Header.Get("ETag") == "" {
+			resp.Header.Set("ETag", cached.ETag)
 		}
-		if resp.StatusCode == http.StatusNoContent {
-			return nil
+		if entry := cacheEntry(resp, cached.Body); entry != nil {
+			c.cache.Set(c.cacheKey(url), entry)
+		} else {
+			// The response is still valid this time, but mustn't be
+			// reused without asking the server again.
+			c.cache.Delete(c.cacheKey(url))
 		}
-		if resp.StatusCode != http.StatusOK {
-			return c.decodeError(resp)
-		}
-
-		err = json.NewDecoder(resp.Body).Decode(result)
-		if err != nil {
-			return err
-		}
-
-		break
+		return json.Unmarshal(cached.Body, result)
+	}
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return c.decodeError(resp)
 	}
 
-	return nil
+	if c.cache == nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if entry := cacheEntry(resp, body); entry != nil {
+		c.cache.Set(c.cacheKey(url), entry)
+	}
+	return json.Unmarshal(body, result)
 }
 
 // NewReleases gets a list of new album releases featured in Spotify.
@@ -300,17 +352,19 @@ func (c *Client) NewReleases(ctx context.Context, opts ...RequestOption) (albums
 		spotifyURL += "?" + params
 	}
 
-	var objmap map[string]*json.RawMessage
-	err = c.get(ctx, spotifyURL, &objmap)
+	var result newReleases
+	err = c.get(ctx, spotifyURL, &result)
 	if err != nil {
 		return nil, err
 	}
+	result.Albums.newWrapper = func() wrappedPage { return new(newReleases) }
 
-	var result SimpleAlbumPage
-	err = json.Unmarshal(*objmap["albums"], &result)
-	if err != nil {
-		return nil, err
-	}
+	return &result.Albums, nil
+}
 
-	return &result, nil
+// newReleases is the object that the new album releases are wrapped in.
+type newReleases struct {
+	Albums SimpleAlbumPage `json:"albums"`
 }
+
+func (n *newReleases) unwrapPage() pageable { return &n.Albums }
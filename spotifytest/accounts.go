@@ -0,0 +1,322 @@
+package spotifytest
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// AccountsServer is a fake implementation of the Spotify Accounts Service.
+// It supports the authorization code flow, with or without PKCE, as well as
+// refreshing tokens and the client credentials flow.  Authorization requests
+// are approved straight away: the server redirects to the redirect URI with
+// a code, without showing any page.
+//
+//	accounts := spotifytest.NewAccountsServer()
+//	defer accounts.Close()
+//	accounts.AddClient("id", "secret")
+//	auth := spotifyauth.New(
+//		spotifyauth.WithClientID("id"),
+//		spotifyauth.WithClientSecret("secret"),
+//		spotifyauth.WithEndpoint(accounts.Endpoint()),
+//	)
+type AccountsServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	next     int
+	lifetime time.Duration
+	deny     bool
+	failures []tokenError
+	clients  map[string]string
+	codes    map[string]authCode
+	refresh  map[string]grant
+	access   map[string]accessToken
+}
+
+// authCode is an authorization code that hasn't been exchanged yet.
+type authCode struct {
+	grant
+	redirectURI string
+	challenge   string
+	method      string
+	expires     time.Time
+}
+
+// grant is what the user authorized a client to do.
+type grant struct {
+	clientID string
+	scope    string
+}
+
+type accessToken struct {
+	grant
+	expires time.Time
+}
+
+// tokenError is an error response of the token endpoint.
+type tokenError struct {
+	status      int
+	code        string
+	description string
+}
+
+// NewAccountsServer starts an AccountsServer that doesn't know any client.
+// Access tokens it issues expire after an hour.  The caller should call
+// Close when finished, to shut it down.
+func NewAccountsServer() *AccountsServer {
+	s := &AccountsServer{
+		lifetime: time.Hour,
+		clients:  make(map[string]string),
+		codes:    make(map[string]authCode),
+		refresh:  make(map[string]grant),
+		access:   make(map[string]accessToken),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/api/token", s.token)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Endpoint returns the endpoint to pass to spotifyauth.WithEndpoint.
+func (s *AccountsServer) Endpoint() oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:  s.URL + "/authorize",
+		TokenURL: s.URL + "/api/token",
+	}
+}
+
+// AddClient registers an application.  A client without a secret can only
+// obtain tokens with PKCE.
+func (s *AccountsServer) AddClient(id, secret string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clients[id] = secret
+}
+
+// SetTokenLifetime sets how long the access tokens issued from now on are valid.
+func (s *AccountsServer) SetTokenLifetime(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lifetime = d
+}
+
+// DenyAuthorization makes the user refuse, or accept again, to authorize
+// the applications that ask for it.
+func (s *AccountsServer) DenyAuthorization(deny bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deny = deny
+}
+
+// FailNext makes the next request to the token endpoint fail with the given
+// HTTP status and OAuth2 error code, such as "invalid_grant".  Calling it
+// several times makes as many requests fail, in order.
+func (s *AccountsServer) FailNext(status int, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, tokenError{status, code, "injected failure"})
+}
+
+// RevokeRefreshToken invalidates a refresh token.
+func (s *AccountsServer) RevokeRefreshToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.refresh, token)
+}
+
+// Valid reports whether an access token was issued by the server and hasn't
+// expired, along with the scopes it was granted, separated by spaces.
+func (s *AccountsServer) Valid(token string) (scope string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.access[token]
+	if !ok || time.Now().After(t.expires) {
+		return "", false
+	}
+	return t.scope, true
+}
+
+// newToken returns a new random-looking, unique token.
+func (s *AccountsServer) newToken(prefix string) string {
+	s.next++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s%d%d", prefix, s.next, time.Now().UnixNano())))
+	return prefix + base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (s *AccountsServer) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[q.Get("client_id")]; !ok {
+		http.Error(w, "INVALID_CLIENT: Invalid client", http.StatusBadRequest)
+		return
+	}
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() {
+		http.Error(w, "INVALID_CLIENT: Invalid redirect URI", http.StatusBadRequest)
+		return
+	}
+	if q.Get("response_type") != "code" {
+		http.Error(w, "unsupported_response_type", http.StatusBadRequest)
+		return
+	}
+	method := q.Get("code_challenge_method")
+	if q.Get("code_challenge") != "" && method != "S256" {
+		http.Error(w, "INVALID_REQUEST: code_challenge_method must be S256", http.StatusBadRequest)
+		return
+	}
+
+	params := redirect.Query()
+	if s.deny {
+		params.Set("error", "access_denied")
+	} else {
+		code := s.newToken("code-")
+		s.codes[code] = authCode{
+			grant:       grant{clientID: q.Get("client_id"), scope: q.Get("scope")},
+			redirectURI: q.Get("redirect_uri"),
+			challenge:   q.Get("code_challenge"),
+			method:      method,
+			expires:     time.Now().Add(10 * time.Minute),
+		}
+		params.Set("code", code)
+	}
+	if state := q.Get("state"); state != "" {
+		params.Set("state", state)
+	}
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (s *AccountsServer) token(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	resp, terr := s.exchange(r)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if terr != nil {
+		w.WriteHeader(terr.status)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error":             terr.code,
+			"error_description": terr.description,
+		})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// tokenResponse is a successful response of the token endpoint.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope"`
+}
+
+func (s *AccountsServer) exchange(r *http.Request) (*tokenResponse, *tokenError) {
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return nil, &err
+	}
+	if r.Method != http.MethodPost {
+		return nil, &tokenError{http.StatusMethodNotAllowed, "invalid_request", "POST required"}
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, &tokenError{http.StatusBadRequest, "invalid_request", "invalid form"}
+	}
+
+	// Clients authenticate either with the Authorization header, in which
+	// case the credentials are URL encoded, or with the form.
+	clientID, secret, basic := r.BasicAuth()
+	if basic {
+		clientID, _ = url.QueryUnescape(clientID)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		clientID = r.PostForm.Get("client_id")
+		secret = r.PostForm.Get("client_secret")
+	}
+	hasSecret := secret != ""
+	want, ok := s.clients[clientID]
+	if !ok {
+		return nil, &tokenError{http.StatusBadRequest, "invalid_client", "Invalid client"}
+	}
+	if hasSecret && (want == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(want)) != 1) {
+		return nil, &tokenError{http.StatusBadRequest, "invalid_client", "Invalid client secret"}
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		code, ok := s.codes[r.PostForm.Get("code")]
+		delete(s.codes, r.PostForm.Get("code"))
+		if !ok || code.clientID != clientID || time.Now().After(code.expires) {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_grant", "Invalid authorization code"}
+		}
+		if code.redirectURI != r.PostForm.Get("redirect_uri") {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_grant", "Invalid redirect URI"}
+		}
+		if code.challenge != "" {
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != code.challenge {
+				return nil, &tokenError{http.StatusBadRequest, "invalid_grant", "code_verifier was incorrect"}
+			}
+		} else if !hasSecret {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_client", "Client secret required"}
+		}
+		return s.issue(code.grant, true), nil
+
+	case "refresh_token":
+		g, ok := s.refresh[r.PostForm.Get("refresh_token")]
+		if !ok || g.clientID != clientID {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_grant", "Invalid refresh token"}
+		}
+		if want != "" && !hasSecret {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_client", "Client secret required"}
+		}
+		resp := s.issue(g, false)
+		// Refresh tokens of public clients are rotated.
+		if want == "" {
+			delete(s.refresh, r.PostForm.Get("refresh_token"))
+			resp.RefreshToken = s.newToken("refresh-")
+			s.refresh[resp.RefreshToken] = g
+		}
+		return resp, nil
+
+	case "client_credentials":
+		if !hasSecret {
+			return nil, &tokenError{http.StatusBadRequest, "invalid_client", "Client secret required"}
+		}
+		return s.issue(grant{clientID: clientID, scope: strings.Join(r.PostForm["scope"], " ")}, false), nil
+	}
+	return nil, &tokenError{http.StatusBadRequest, "unsupported_grant_type", "grant_type must be authorization_code, refresh_token or client_credentials"}
+}
+
+// issue creates an access token, and a refresh token if withRefresh is true.
+func (s *AccountsServer) issue(g grant, withRefresh bool) *tokenResponse {
+	resp := &tokenResponse{
+		AccessToken: s.newToken("access-"),
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.lifetime / time.Second),
+		Scope:       g.scope,
+	}
+	s.access[resp.AccessToken] = accessToken{grant: g, expires: time.Now().Add(s.lifetime)}
+	if withRefresh {
+		resp.RefreshToken = s.newToken("refresh-")
+		s.refresh[resp.RefreshToken] = g
+	}
+	return resp
+}
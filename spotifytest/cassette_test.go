@@ -0,0 +1,151 @@
+package spotifytest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/artists/0TnOYISbd1XYRBk9myaseg":
+			_, _ = io.WriteString(w, `{"id":"0TnOYISbd1XYRBk9myaseg","name":"Pitbull"}`)
+		case "/api/token":
+			_, _ = io.WriteString(w, `{"access_token":"secret-access","refresh_token":"secret-refresh","token_type":"Bearer"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette, nil)
+
+	req, _ := http.NewRequest("GET", server.URL+"/artists/0TnOYISbd1XYRBk9myaseg", nil)
+	req.Header.Set("Authorization", "Bearer secret-access")
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	form := url.Values{"grant_type": {"authorization_code"}, "code": {"secret-code"}}
+	req, _ = http.NewRequest("POST", server.URL+"/api/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err = recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "secret-access") {
+		t.Error("Recorder modified the response seen by the caller")
+	}
+
+	if s := cassette.String(); strings.Contains(s, "secret") {
+		t.Errorf("Cassette contains a credential:\n%s", s)
+	}
+	if n := strings.Count(cassette.String(), "\n"); n != 2 {
+		t.Fatalf("Expected 2 interactions, got %d", n)
+	}
+
+	replayer, err := NewReplayer(&cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := spotify.New(
+		spotify.WithHTTPClient(replayer.Client()),
+		spotify.WithBaseURL("http://replay.invalid/"),
+	)
+	artist, err := client.GetArtist(context.Background(), "0TnOYISbd1XYRBk9myaseg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.Name != "Pitbull" {
+		t.Errorf("Expected Pitbull, got %q", artist.Name)
+	}
+
+	// the form is scrubbed the same way before matching
+	req, _ = http.NewRequest("POST", "http://replay.invalid/api/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := replayer.RoundTrip(req); err != nil {
+		t.Error(err)
+	}
+	if n := replayer.Remaining(); n != 0 {
+		t.Errorf("Expected every interaction to be replayed, %d left", n)
+	}
+}
+
+func TestRecorderLeavesRequestBody(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = string(b)
+	}))
+	defer server.Close()
+	var cassette bytes.Buffer
+	recorder := NewRecorder(&cassette, nil)
+
+	for _, body := range []io.Reader{
+		strings.NewReader(`{"name":"A"}`),
+		// no GetBody
+		io.MultiReader(strings.NewReader(`{"name":"A"}`)),
+	} {
+		req, _ := http.NewRequest("PUT", server.URL+"/playlists/1", body)
+		original := req.Body
+		resp, err := recorder.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if req.Body != original {
+			t.Error("Recorder replaced the request body")
+		}
+		if received != `{"name":"A"}` {
+			t.Errorf("Server received %q", received)
+		}
+	}
+	if n := strings.Count(cassette.String(), `{\"name\":\"A\"}`); n != 2 {
+		t.Errorf("Expected the body to be recorded twice, got %d:\n%s", n, cassette.String())
+	}
+}
+
+func TestReplayerUnmatched(t *testing.T) {
+	cassette := `{"request":{"method":"GET","url":"http://api.spotify.com/v1/me?market=US"},"response":{"status":200,"body":"{}"}}
+{"request":{"method":"GET","url":"http://api.spotify.com/v1/me?market=US"},"response":{"status":404}}
+`
+	replayer, err := NewReplayer(strings.NewReader(cassette))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(u string) (*http.Response, error) {
+		req, _ := http.NewRequest("GET", u, nil)
+		return replayer.RoundTrip(req)
+	}
+
+	if _, err := get("http://localhost/v1/me?market=GB"); err == nil {
+		t.Error("Expected an error for a different query")
+	}
+	for _, want := range []int{http.StatusOK, http.StatusNotFound} {
+		resp, err := get("http://localhost/v1/me?market=US")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("Expected HTTP %d, got %d", want, resp.StatusCode)
+		}
+	}
+	if _, err := get("http://localhost/v1/me?market=US"); err == nil {
+		t.Error("Expected an error once the recorded responses are used up")
+	}
+}
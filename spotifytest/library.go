@@ -0,0 +1,215 @@
+package spotifytest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+func (s *Server) routeLibrary(r *request) (*response, *requestError) {
+	p := r.path
+	if p[1] == "following" {
+		return s.routeFollowing(r)
+	}
+
+	library, exists := &s.savedTracks, func(id spotify.ID) bool { _, ok := s.tracks[id]; return ok }
+	if p[1] == "albums" {
+		library, exists = &s.savedAlbums, func(id spotify.ID) bool { _, ok := s.albums[id]; return ok }
+	}
+
+	switch {
+	case len(p) == 2 && r.Method == http.MethodGet:
+		return s.savedPage(r, p[1], *library)
+	case len(p) == 2 && (r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		ids, err := r.ids(50)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !exists(id) {
+				return nil, errorf(http.StatusBadRequest, "invalid id")
+			}
+		}
+		if r.Method == http.MethodPut {
+			*library = addSaved(*library, ids)
+		} else {
+			*library = removeSaved(*library, ids)
+		}
+		return respond(nil), nil
+	case len(p) == 3 && p[2] == "contains" && r.Method == http.MethodGet:
+		ids, err := r.ids(50)
+		if err != nil {
+			return nil, err
+		}
+		contains := make([]bool, len(ids))
+		for i, id := range ids {
+			contains[i] = indexSaved(*library, id) >= 0
+		}
+		return respond(contains), nil
+	}
+	return nil, errNotFound
+}
+
+func indexSaved(library []saved, id spotify.ID) int {
+	for i, item := range library {
+		if item.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// addSaved adds the IDs that aren't in library yet to its front,
+// so that the most recently saved items come first.
+func addSaved(library []saved, ids []spotify.ID) []saved {
+	now := time.Now().UTC()
+	for _, id := range ids {
+		if indexSaved(library, id) < 0 {
+			library = append([]saved{{id: id, addedAt: now}}, library...)
+		}
+	}
+	return library
+}
+
+func removeSaved(library []saved, ids []spotify.ID) []saved {
+	for _, id := range ids {
+		if i := indexSaved(library, id); i >= 0 {
+			library = append(library[:i:i], library[i+1:]...)
+		}
+	}
+	return library
+}
+
+func (s *Server) savedPage(r *request, typ string, library []saved) (*response, *requestError) {
+	pg, start, end, err := s.newPage(r, len(library), 20, 50)
+	if err != nil {
+		return nil, err
+	}
+	if typ == "albums" {
+		albums := make([]spotify.SavedAlbum, 0, end-start)
+		for _, item := range library[start:end] {
+			albums = append(albums, spotify.SavedAlbum{
+				AddedAt:   item.addedAt.Format(spotify.TimestampLayout),
+				FullAlbum: *s.album(item.id),
+			})
+		}
+		pg.Items = albums
+	} else {
+		tracks := make([]spotify.SavedTrack, 0, end-start)
+		for _, item := range library[start:end] {
+			tracks = append(tracks, spotify.SavedTrack{
+				AddedAt:   item.addedAt.Format(spotify.TimestampLayout),
+				FullTrack: s.tracks[item.id],
+			})
+		}
+		pg.Items = tracks
+	}
+	return respond(pg), nil
+}
+
+func (s *Server) routeFollowing(r *request) (*response, *requestError) {
+	typ := r.query.Get("type")
+	following, exists := &s.followedArtists, func(id spotify.ID) bool { _, ok := s.artists[id]; return ok }
+	switch typ {
+	case "artist":
+	case "user":
+		following, exists = &s.followedUsers, func(id spotify.ID) bool { _, ok := s.users[string(id)]; return ok }
+	default:
+		return nil, errorf(http.StatusBadRequest, "Invalid type")
+	}
+
+	p := r.path
+	switch {
+	case len(p) == 2 && r.Method == http.MethodGet:
+		if typ != "artist" {
+			return nil, errorf(http.StatusBadRequest, "Only artist type is supported")
+		}
+		return s.followedArtistsPage(r)
+	case len(p) == 2 && (r.Method == http.MethodPut || r.Method == http.MethodDelete):
+		ids, err := r.ids(50)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			if !exists(id) {
+				return nil, errorf(http.StatusBadRequest, "invalid id")
+			}
+		}
+		for _, id := range ids {
+			i := indexID(*following, id)
+			switch {
+			case r.Method == http.MethodPut && i < 0:
+				*following = append(*following, id)
+			case r.Method == http.MethodDelete && i >= 0:
+				*following = append((*following)[:i:i], (*following)[i+1:]...)
+			}
+		}
+		return noContent(), nil
+	case len(p) == 3 && p[2] == "contains" && r.Method == http.MethodGet:
+		ids, err := r.ids(50)
+		if err != nil {
+			return nil, err
+		}
+		follows := make([]bool, len(ids))
+		for i, id := range ids {
+			follows[i] = indexID(*following, id) >= 0
+		}
+		return respond(follows), nil
+	}
+	return nil, errNotFound
+}
+
+func indexID(ids []spotify.ID, id spotify.ID) int {
+	for i := range ids {
+		if ids[i] == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// followedArtistsPage lists the followed artists in a cursor-based paging
+// object.  The cursor is the ID of the last artist of the page.
+func (s *Server) followedArtistsPage(r *request) (*response, *requestError) {
+	limit := 20
+	if v := r.query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 50 {
+			return nil, errorf(http.StatusBadRequest, "Invalid limit")
+		}
+		limit = n
+	}
+	start := 0
+	if after := r.query.Get("after"); after != "" {
+		start = indexID(s.followedArtists, spotify.ID(after)) + 1
+	}
+	end := start + limit
+	if end > len(s.followedArtists) {
+		end = len(s.followedArtists)
+	}
+
+	artists := make([]spotify.FullArtist, 0, end-start)
+	for _, id := range s.followedArtists[start:end] {
+		artists = append(artists, s.artists[id])
+	}
+	var pg struct {
+		Endpoint string               `json:"href"`
+		Items    []spotify.FullArtist `json:"items"`
+		Limit    int                  `json:"limit"`
+		Next     *string              `json:"next"`
+		Total    int                  `json:"total"`
+		Cursors  spotify.Cursor       `json:"cursors"`
+	}
+	pg.Endpoint = s.URL + r.URL.RequestURI()
+	pg.Items = artists
+	pg.Limit = limit
+	pg.Total = len(s.followedArtists)
+	if end < len(s.followedArtists) {
+		pg.Cursors.After = string(artists[len(artists)-1].ID)
+		next := s.BaseURL() + "me/following?type=artist&limit=" + strconv.Itoa(limit) + "&after=" + pg.Cursors.After
+		pg.Next = &next
+	}
+	return respond(map[string]interface{}{"artists": pg}), nil
+}
@@ -0,0 +1,425 @@
+package spotifytest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+// player is the playback state of the current user.  Playback doesn't
+// progress on its own: the position only changes when seeking, and tracks
+// only change when skipping.
+type player struct {
+	// device is the ID of the active device, if any.
+	device  spotify.ID
+	playing bool
+	// context is the URI of the album or playlist being played, if any,
+	// and tracks are the tracks being played, in order.
+	context  spotify.URI
+	tracks   []spotify.ID
+	index    int
+	item     spotify.ID
+	progress int
+	shuffle  bool
+	repeat   string
+	queue    []spotify.ID
+	// history holds the tracks played, most recent first.
+	history []spotify.RecentlyPlayedItem
+}
+
+// Played returns the IDs of the tracks that playback went through,
+// most recent first.
+func (s *Server) Played() []spotify.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]spotify.ID, len(s.player.history))
+	for i, item := range s.player.history {
+		ids[i] = item.Track.ID
+	}
+	return ids
+}
+
+func (s *Server) routePlayer(r *request) (*response, *requestError) {
+	action := ""
+	if len(r.path) == 3 {
+		action = r.path[2]
+	} else if len(r.path) > 3 {
+		return nil, errNotFound
+	}
+
+	switch action {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			if s.player.device == "" {
+				return noContent(), nil
+			}
+			return respond(s.playerState()), nil
+		case http.MethodPut:
+			return s.transferPlayback(r)
+		}
+		return nil, errNotFound
+	case "devices":
+		devices := make([]spotify.PlayerDevice, len(s.devices))
+		for i, d := range s.devices {
+			d.Active = d.ID == s.player.device
+			devices[i] = d
+		}
+		return respond(map[string]interface{}{"devices": devices}), nil
+	case "currently-playing":
+		if s.player.device == "" || s.player.item == "" {
+			return noContent(), nil
+		}
+		return respond(s.playerState().CurrentlyPlaying), nil
+	case "recently-played":
+		return s.recentlyPlayed(r)
+	}
+
+	if err := s.selectDevice(r); err != nil {
+		return nil, err
+	}
+	method := http.MethodPut
+	if action == "queue" || action == "next" || action == "previous" {
+		method = http.MethodPost
+	}
+	if r.Method != method {
+		return nil, errNotFound
+	}
+
+	pl := &s.player
+	switch action {
+	case "play":
+		return s.play(r)
+	case "pause":
+		if !pl.playing {
+			return nil, &requestError{http.StatusForbidden, spotify.ReasonAlreadyPaused, "Player command failed: Already paused"}
+		}
+		pl.playing = false
+	case "queue":
+		ids, err := s.trackIDs([]string{r.query.Get("uri")})
+		if err != nil {
+			return nil, err
+		}
+		pl.queue = append(pl.queue, ids[0])
+	case "next":
+		s.skip(1)
+	case "previous":
+		if pl.index == 0 || len(pl.tracks) == 0 {
+			return nil, &requestError{http.StatusForbidden, spotify.ReasonNoPreviousTrack, "Player command failed: No previous track"}
+		}
+		s.skip(-1)
+	case "seek":
+		position, err := strconv.Atoi(r.query.Get("position_ms"))
+		if err != nil || position < 0 {
+			return nil, errorf(http.StatusBadRequest, "Invalid position_ms")
+		}
+		if t, ok := s.tracks[pl.item]; ok && position > t.Duration {
+			s.skip(1)
+		} else {
+			pl.progress = position
+		}
+	case "repeat":
+		state := r.query.Get("state")
+		if state != "track" && state != "context" && state != "off" {
+			return nil, errorf(http.StatusBadRequest, "Invalid repeat state")
+		}
+		pl.repeat = state
+	case "volume":
+		percent, err := strconv.Atoi(r.query.Get("volume_percent"))
+		if err != nil || percent < 0 || percent > 100 {
+			return nil, errorf(http.StatusBadRequest, "Invalid volume_percent")
+		}
+		for i := range s.devices {
+			if s.devices[i].ID == pl.device {
+				s.devices[i].Volume = percent
+			}
+		}
+	case "shuffle":
+		shuffle, err := strconv.ParseBool(r.query.Get("state"))
+		if err != nil {
+			return nil, errorf(http.StatusBadRequest, "Invalid state")
+		}
+		pl.shuffle = shuffle
+	default:
+		return nil, errNotFound
+	}
+	return noContent(), nil
+}
+
+// selectDevice checks that a player command can be sent, and makes the
+// device it targets, if any, the active device.
+func (s *Server) selectDevice(r *request) *requestError {
+	if s.user.Product != "premium" {
+		return errPremium
+	}
+	if id := spotify.ID(r.query.Get("device_id")); id != "" {
+		if s.device(id) == nil {
+			return errorf(http.StatusNotFound, "Device not found")
+		}
+		s.player.device = id
+	}
+	if s.player.device == "" {
+		return errNoActiveDevice
+	}
+	return nil
+}
+
+func (s *Server) device(id spotify.ID) *spotify.PlayerDevice {
+	for i := range s.devices {
+		if s.devices[i].ID == id {
+			return &s.devices[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) playerState() *spotify.PlayerState {
+	pl := s.player
+	state := &spotify.PlayerState{
+		CurrentlyPlaying: spotify.CurrentlyPlaying{
+			Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+			Progress:  pl.progress,
+			Playing:   pl.playing,
+		},
+		ShuffleState: pl.shuffle,
+		RepeatState:  pl.repeat,
+	}
+	if d := s.device(pl.device); d != nil {
+		state.Device = *d
+		state.Device.Active = true
+	}
+	if pl.context != "" {
+		state.PlaybackContext = s.playbackContext(pl.context)
+	}
+	if t, ok := s.tracks[pl.item]; ok {
+		state.Item = &t
+		state.CurrentlyPlayingType = "track"
+	}
+	return state
+}
+
+func (s *Server) playbackContext(uri spotify.URI) spotify.PlaybackContext {
+	parts := strings.Split(string(uri), ":")
+	typ, id := parts[1], parts[2]
+	return spotify.PlaybackContext{
+		Endpoint: s.BaseURL() + typ + "s/" + id,
+		Type:     typ,
+		URI:      uri,
+	}
+}
+
+func (s *Server) transferPlayback(r *request) (*response, *requestError) {
+	if s.user.Product != "premium" {
+		return nil, errPremium
+	}
+	body := struct {
+		DeviceIDs []spotify.ID `json:"device_ids"`
+		Play      bool         `json:"play"`
+	}{}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.DeviceIDs) != 1 {
+		return nil, errorf(http.StatusBadRequest, "Exactly one device ID must be given")
+	}
+	if s.device(body.DeviceIDs[0]) == nil {
+		return nil, errorf(http.StatusNotFound, "Device not found")
+	}
+	s.player.device = body.DeviceIDs[0]
+	if body.Play {
+		s.player.playing = true
+	}
+	return noContent(), nil
+}
+
+func (s *Server) play(r *request) (*response, *requestError) {
+	var opt spotify.PlayOptions
+	if r.ContentLength != 0 {
+		if err := r.decode(&opt); err != nil && r.ContentLength > 0 {
+			return nil, err
+		}
+	}
+
+	pl := &s.player
+	if opt.PlaybackContext == nil && opt.URIs == nil {
+		// resume playback
+		if pl.item == "" {
+			return nil, &requestError{http.StatusForbidden, spotify.ReasonUnknown, "Player command failed: Nothing to play"}
+		}
+		pl.playing = true
+		return noContent(), nil
+	}
+
+	var tracks []spotify.ID
+	var context spotify.URI
+	if opt.PlaybackContext != nil {
+		context = *opt.PlaybackContext
+		var err *requestError
+		tracks, err = s.contextTracks(context)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		uris := make([]string, len(opt.URIs))
+		for i, uri := range opt.URIs {
+			uris[i] = string(uri)
+		}
+		var err *requestError
+		tracks, err = s.trackIDs(uris)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(tracks) == 0 {
+		return nil, errorf(http.StatusBadRequest, "Nothing to play")
+	}
+
+	index := 0
+	if o := opt.PlaybackOffset; o != nil {
+		index = o.Position
+		if o.URI != "" {
+			index = indexID(tracks, spotify.ID(strings.TrimPrefix(string(o.URI), "spotify:track:")))
+		}
+		if index < 0 || index >= len(tracks) {
+			return nil, errorf(http.StatusBadRequest, "Invalid offset")
+		}
+	}
+
+	s.recordPlayed()
+	pl.context, pl.tracks, pl.index = context, tracks, index
+	pl.item, pl.progress, pl.playing = tracks[index], opt.PositionMs, true
+	return noContent(), nil
+}
+
+// contextTracks returns the tracks of the album or playlist identified by uri.
+func (s *Server) contextTracks(uri spotify.URI) ([]spotify.ID, *requestError) {
+	parts := strings.Split(string(uri), ":")
+	if len(parts) != 3 || parts[0] != "spotify" {
+		return nil, errorf(http.StatusBadRequest, "Invalid context uri")
+	}
+	id := spotify.ID(parts[2])
+	var tracks []spotify.ID
+	switch parts[1] {
+	case "album":
+		if _, ok := s.albums[id]; !ok {
+			return nil, errorf(http.StatusNotFound, "Album not found")
+		}
+		for _, t := range s.albumTracks(id) {
+			tracks = append(tracks, t.ID)
+		}
+	case "playlist":
+		p, ok := s.playlists[id]
+		if !ok {
+			return nil, errorf(http.StatusNotFound, "Playlist not found")
+		}
+		for _, e := range p.entries {
+			tracks = append(tracks, e.track)
+		}
+	default:
+		return nil, errorf(http.StatusBadRequest, "Unsupported context uri")
+	}
+	return tracks, nil
+}
+
+// recordPlayed adds the current track to the history.
+func (s *Server) recordPlayed() {
+	pl := &s.player
+	t, ok := s.tracks[pl.item]
+	if !ok {
+		return
+	}
+	item := spotify.RecentlyPlayedItem{Track: t.SimpleTrack, PlayedAt: time.Now().UTC()}
+	if pl.context != "" {
+		item.PlaybackContext = s.playbackContext(pl.context)
+	}
+	pl.history = append([]spotify.RecentlyPlayedItem{item}, pl.history...)
+}
+
+// skip moves playback n tracks forward or backward.  Queued tracks are
+// played before moving forward in the context.
+func (s *Server) skip(n int) {
+	pl := &s.player
+	s.recordPlayed()
+	pl.progress = 0
+
+	if n > 0 && len(pl.queue) > 0 {
+		pl.item, pl.queue = pl.queue[0], pl.queue[1:]
+		pl.playing = true
+		return
+	}
+	if len(pl.tracks) == 0 {
+		pl.item, pl.playing = "", false
+		return
+	}
+
+	pl.index += n
+	switch {
+	case pl.index < 0:
+		pl.index = 0
+	case pl.index >= len(pl.tracks) && pl.repeat == "context":
+		pl.index = 0
+	case pl.index >= len(pl.tracks):
+		pl.index = len(pl.tracks) - 1
+		pl.item, pl.playing = "", false
+		return
+	}
+	pl.item = pl.tracks[pl.index]
+	pl.playing = true
+}
+
+// recentlyPlayed lists the history in a cursor-based paging object,
+// whose cursors are the times the tracks were played, in milliseconds.
+func (s *Server) recentlyPlayed(r *request) (*response, *requestError) {
+	limit := 20
+	if v := r.query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 50 {
+			return nil, errorf(http.StatusBadRequest, "Invalid limit")
+		}
+		limit = n
+	}
+	millis := func(t time.Time) int64 { return t.UnixNano() / int64(time.Millisecond) }
+
+	var items []spotify.RecentlyPlayedItem
+	before, _ := strconv.ParseInt(r.query.Get("before"), 10, 64)
+	after, _ := strconv.ParseInt(r.query.Get("after"), 10, 64)
+	for _, item := range s.player.history {
+		ms := millis(item.PlayedAt)
+		if (before == 0 || ms < before) && ms > after {
+			items = append(items, item)
+		}
+	}
+	more := len(items) > limit
+	if more {
+		if after != 0 {
+			items = items[len(items)-limit:]
+		} else {
+			items = items[:limit]
+		}
+	}
+
+	var pg struct {
+		Endpoint string                       `json:"href"`
+		Items    []spotify.RecentlyPlayedItem `json:"items"`
+		Limit    int                          `json:"limit"`
+		Next     *string                      `json:"next"`
+		Cursors  *spotify.Cursor              `json:"cursors"`
+	}
+	pg.Endpoint = s.URL + r.URL.RequestURI()
+	pg.Items = items
+	pg.Limit = limit
+	if len(items) > 0 {
+		pg.Cursors = &spotify.Cursor{
+			After:  strconv.FormatInt(millis(items[0].PlayedAt), 10),
+			Before: strconv.FormatInt(millis(items[len(items)-1].PlayedAt), 10),
+		}
+		if more && after == 0 {
+			next := s.BaseURL() + "me/player/recently-played?limit=" + strconv.Itoa(limit) + "&before=" + pg.Cursors.Before
+			pg.Next = &next
+		}
+	}
+	return respond(pg), nil
+}
@@ -0,0 +1,96 @@
+package spotifytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+func TestServerPlayback(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+	srv.AddDevices(
+		spotify.PlayerDevice{ID: "phone", Name: "Phone", Type: "Smartphone", Volume: 50},
+		spotify.PlayerDevice{ID: "laptop", Name: "Laptop", Type: "Computer", Volume: 50},
+	)
+
+	err := client.Play(ctx)
+	if !errors.Is(err, spotify.ErrNoActiveDevice) {
+		t.Errorf("Expected ErrNoActiveDevice, got %v", err)
+	}
+	state, err := client.PlayerState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Device.ID != "" {
+		t.Errorf("Expected no active device, got %+v", state.Device)
+	}
+
+	if err := client.TransferPlayback(ctx, "laptop", false); err != nil {
+		t.Fatal(err)
+	}
+	album := spotify.URI("spotify:album:album1")
+	err = client.PlayOpt(ctx, &spotify.PlayOptions{
+		PlaybackContext: &album,
+		PlaybackOffset:  &spotify.PlaybackOffset{Position: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.QueueSong(ctx, "track4"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Next(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Volume(ctx, 80); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = client.PlayerState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Playing || state.Item == nil || state.Item.ID != "track1" {
+		t.Errorf("Expected track1 to be playing, got %+v", state.CurrentlyPlaying)
+	}
+	if state.Device.ID != "laptop" || state.Device.Volume != 80 {
+		t.Errorf("Unexpected device %+v", state.Device)
+	}
+	if state.PlaybackContext.URI != album {
+		t.Errorf("Unexpected context %+v", state.PlaybackContext)
+	}
+
+	played, err := client.PlayerRecentlyPlayed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(played) != 2 || played[0].Track.ID != "track4" || played[1].Track.ID != "track2" {
+		t.Errorf("Unexpected history %+v", played)
+	}
+
+	if err := client.Pause(ctx); err != nil {
+		t.Fatal(err)
+	}
+	err = client.Pause(ctx)
+	var serr spotify.Error
+	if !errors.As(err, &serr) || serr.Reason != spotify.ReasonAlreadyPaused {
+		t.Errorf("Expected ALREADY_PAUSED, got %v", err)
+	}
+}
+
+func TestServerPlaybackRequiresPremium(t *testing.T) {
+	srv, client := newTestServer(t)
+	srv.SetCurrentUser(spotify.PrivateUser{User: spotify.User{ID: "free-user"}, Product: "free"})
+	srv.AddDevices(spotify.PlayerDevice{ID: "phone", Active: true})
+
+	err := client.Next(context.Background())
+	if !errors.Is(err, spotify.ErrPremiumRequired) {
+		t.Errorf("Expected ErrPremiumRequired, got %v", err)
+	}
+}
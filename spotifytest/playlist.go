@@ -0,0 +1,489 @@
+package spotifytest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+// playlist is a playlist stored by a Server.
+type playlist struct {
+	id            spotify.ID
+	owner         string
+	name          string
+	description   string
+	public        bool
+	collaborative bool
+	images        []spotify.Image
+	// followers maps the IDs of the users following the playlist
+	// to whether they follow it publicly.
+	followers map[string]bool
+
+	entries []entry
+	// snapshots holds the entries of every version of the playlist.
+	snapshots  map[string][]entry
+	snapshotID string
+	version    int
+	nextKey    int
+}
+
+// entry is an occurrence of a track in a playlist.  Its key tells apart
+// several occurrences of the same track.
+type entry struct {
+	key     int
+	track   spotify.ID
+	addedBy string
+	addedAt time.Time
+}
+
+// commit records the current entries of the playlist as a new version.
+func (p *playlist) commit() {
+	p.version++
+	p.snapshotID = base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d,%s", p.version, p.id)))
+	p.snapshots[p.snapshotID] = append([]entry(nil), p.entries...)
+}
+
+// snapshot returns the entries of the version of the playlist identified by
+// snapshotID, or of the current version if snapshotID is empty.
+func (p *playlist) snapshot(snapshotID string) ([]entry, *requestError) {
+	if snapshotID == "" {
+		return p.entries, nil
+	}
+	entries, ok := p.snapshots[snapshotID]
+	if !ok {
+		return nil, errorf(http.StatusBadRequest, "Invalid snapshot id")
+	}
+	return entries, nil
+}
+
+// CreatePlaylist adds a playlist owned by the current user, as if it had been
+// created with the API, and returns its ID.
+func (s *Server) CreatePlaylist(name string, public bool, tracks ...spotify.ID) spotify.ID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.newPlaylist(s.user.ID, name, "", public, false)
+	for _, id := range tracks {
+		p.entries = append(p.entries, s.newEntry(p, id))
+	}
+	p.commit()
+	return p.id
+}
+
+func (s *Server) newPlaylist(owner, name, description string, public, collaborative bool) *playlist {
+	p := &playlist{
+		id:            s.newID(),
+		owner:         owner,
+		name:          name,
+		description:   description,
+		public:        public,
+		collaborative: collaborative,
+		followers:     map[string]bool{owner: public},
+		snapshots:     make(map[string][]entry),
+	}
+	s.playlists[p.id] = p
+	s.playlistOrder = append(s.playlistOrder, p.id)
+	return p
+}
+
+func (s *Server) newEntry(p *playlist, track spotify.ID) entry {
+	p.nextKey++
+	return entry{key: p.nextKey, track: track, addedBy: s.user.ID, addedAt: time.Now().UTC()}
+}
+
+func (s *Server) simplePlaylist(p *playlist) spotify.SimplePlaylist {
+	endpoint := s.BaseURL() + "playlists/" + string(p.id)
+	return spotify.SimplePlaylist{
+		Collaborative: p.collaborative,
+		Endpoint:      endpoint,
+		ID:            p.id,
+		Images:        p.images,
+		Name:          p.name,
+		Owner:         s.users[p.owner],
+		IsPublic:      p.public,
+		SnapshotID:    p.snapshotID,
+		Tracks: spotify.PlaylistTracks{
+			Endpoint: endpoint + "/tracks",
+			Total:    uint(len(p.entries)),
+		},
+		URI: spotify.NewURI(spotify.KindPlaylist, p.id),
+	}
+}
+
+func (s *Server) playlistTracks(p *playlist) []spotify.PlaylistTrack {
+	tracks := make([]spotify.PlaylistTrack, len(p.entries))
+	for i, e := range p.entries {
+		tracks[i] = spotify.PlaylistTrack{
+			AddedAt: e.addedAt.Format(spotify.TimestampLayout),
+			AddedBy: s.users[e.addedBy],
+			Track:   s.tracks[e.track],
+		}
+	}
+	return tracks
+}
+
+func (s *Server) fullPlaylist(p *playlist) *spotify.FullPlaylist {
+	full := &spotify.FullPlaylist{
+		SimplePlaylist: s.simplePlaylist(p),
+		Description:    p.description,
+		Followers:      spotify.Followers{Count: uint(len(p.followers))},
+	}
+	full.Tracks.Endpoint = full.SimplePlaylist.Tracks.Endpoint
+	tracks := s.playlistTracks(p)
+	if len(tracks) > 100 {
+		tracks = tracks[:100]
+		full.Tracks.Next = full.Tracks.Endpoint + "?offset=100&limit=100"
+	}
+	full.Tracks.Tracks = tracks
+	full.Tracks.Limit = 100
+	full.Tracks.Total = len(p.entries)
+	return full
+}
+
+func (s *Server) routePlaylists(r *request) (*response, *requestError) {
+	path := r.path
+	if len(path) < 2 {
+		return nil, errNotFound
+	}
+	p, ok := s.playlists[spotify.ID(path[1])]
+	if !ok {
+		return nil, errorf(http.StatusNotFound, "Not found.")
+	}
+
+	switch {
+	case len(path) == 2:
+		switch r.Method {
+		case http.MethodGet:
+			return respond(s.fullPlaylist(p)), nil
+		case http.MethodPut:
+			return s.changePlaylistDetails(r, p)
+		}
+	case path[2] == "tracks" && len(path) == 3:
+		switch r.Method {
+		case http.MethodGet:
+			tracks := s.playlistTracks(p)
+			pg, start, end, err := s.newPage(r, len(tracks), 100, 100)
+			if err != nil {
+				return nil, err
+			}
+			pg.Items = tracks[start:end]
+			return respond(pg), nil
+		case http.MethodPost:
+			return s.addPlaylistTracks(r, p)
+		case http.MethodDelete:
+			return s.removePlaylistTracks(r, p)
+		case http.MethodPut:
+			if r.query.Get("uris") != "" {
+				return s.replacePlaylistTracks(r, p)
+			}
+			return s.reorderPlaylistTracks(r, p)
+		}
+	case path[2] == "followers":
+		switch {
+		case len(path) == 3 && r.Method == http.MethodPut:
+			public := true
+			if r.ContentLength != 0 {
+				if err := r.decode(&public); err != nil {
+					return nil, err
+				}
+			}
+			p.followers[s.user.ID] = public
+			return respond(nil), nil
+		case len(path) == 3 && r.Method == http.MethodDelete:
+			delete(p.followers, s.user.ID)
+			return respond(nil), nil
+		case len(path) == 4 && path[3] == "contains" && r.Method == http.MethodGet:
+			ids := strings.Split(r.query.Get("ids"), ",")
+			if len(ids) > 5 {
+				return nil, errorf(http.StatusBadRequest, "Too many ids requested")
+			}
+			follows := make([]bool, len(ids))
+			for i, id := range ids {
+				public, ok := p.followers[id]
+				follows[i] = ok && (public || id == s.user.ID)
+			}
+			return respond(follows), nil
+		}
+	case path[2] == "images" && len(path) == 3:
+		switch r.Method {
+		case http.MethodGet:
+			images := p.images
+			if images == nil {
+				images = []spotify.Image{}
+			}
+			return respond(images), nil
+		case http.MethodPut:
+			return s.setPlaylistImage(r, p)
+		}
+	}
+	return nil, errNotFound
+}
+
+// checkOwner returns an error unless the current user may modify p.
+func (s *Server) checkOwner(p *playlist) *requestError {
+	if p.owner != s.user.ID && !p.collaborative {
+		return errorf(http.StatusForbidden, "You cannot modify a playlist you don't own.")
+	}
+	return nil
+}
+
+func (s *Server) createPlaylist(r *request, userID string) (*response, *requestError) {
+	if userID != s.user.ID {
+		return nil, errorf(http.StatusForbidden, "You cannot create a playlist for another user")
+	}
+	body := struct {
+		Name          string `json:"name"`
+		Public        *bool  `json:"public"`
+		Description   string `json:"description"`
+		Collaborative bool   `json:"collaborative"`
+	}{}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if body.Name == "" {
+		return nil, errorf(http.StatusBadRequest, "Missing required field: name")
+	}
+	public := body.Public == nil || *body.Public
+	if body.Collaborative && public {
+		return nil, errorf(http.StatusBadRequest, "Collaborative playlists cannot be public")
+	}
+
+	p := s.newPlaylist(userID, body.Name, body.Description, public, body.Collaborative)
+	p.commit()
+	return &response{status: http.StatusCreated, body: s.fullPlaylist(p)}, nil
+}
+
+func (s *Server) changePlaylistDetails(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	body := struct {
+		Name          *string `json:"name"`
+		Public        *bool   `json:"public"`
+		Collaborative *bool   `json:"collaborative"`
+		Description   *string `json:"description"`
+	}{}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if body.Name != nil {
+		p.name = *body.Name
+	}
+	if body.Public != nil {
+		p.public = *body.Public
+	}
+	if body.Collaborative != nil {
+		p.collaborative = *body.Collaborative
+	}
+	if body.Description != nil {
+		p.description = *body.Description
+	}
+	return respond(nil), nil
+}
+
+// trackIDs converts track URIs to the IDs of tracks in the catalog.
+func (s *Server) trackIDs(uris []string) ([]spotify.ID, *requestError) {
+	ids := make([]spotify.ID, len(uris))
+	for i, uri := range uris {
+		id := spotify.ID(strings.TrimPrefix(uri, "spotify:track:"))
+		if _, ok := s.tracks[id]; !ok || !strings.HasPrefix(uri, "spotify:track:") {
+			return nil, errorf(http.StatusBadRequest, "Invalid track uri: %s", uri)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func (s *Server) addPlaylistTracks(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	body := struct {
+		URIs     []string `json:"uris"`
+		Position *int     `json:"position"`
+	}{}
+	if v := r.query.Get("uris"); v != "" {
+		body.URIs = strings.Split(v, ",")
+	} else if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.URIs) == 0 || len(body.URIs) > 100 {
+		return nil, errorf(http.StatusBadRequest, "You can add a maximum of 100 tracks per request.")
+	}
+	ids, err := s.trackIDs(body.URIs)
+	if err != nil {
+		return nil, err
+	}
+
+	position := len(p.entries)
+	if body.Position != nil {
+		if *body.Position < 0 || *body.Position > len(p.entries) {
+			return nil, errorf(http.StatusBadRequest, "Index out of bounds")
+		}
+		position = *body.Position
+	}
+	added := make([]entry, len(ids))
+	for i, id := range ids {
+		added[i] = s.newEntry(p, id)
+	}
+	entries := append([]entry(nil), p.entries[:position]...)
+	entries = append(entries, added...)
+	p.entries = append(entries, p.entries[position:]...)
+	p.commit()
+
+	return &response{status: http.StatusCreated, body: snapshot(p)}, nil
+}
+
+func snapshot(p *playlist) interface{} {
+	return map[string]string{"snapshot_id": p.snapshotID}
+}
+
+func (s *Server) removePlaylistTracks(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	body := struct {
+		Tracks []struct {
+			URI       string `json:"uri"`
+			Positions []int  `json:"positions"`
+		} `json:"tracks"`
+		SnapshotID string `json:"snapshot_id"`
+	}{}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.Tracks) == 0 || len(body.Tracks) > 100 {
+		return nil, errorf(http.StatusBadRequest, "You can remove a maximum of 100 tracks per request.")
+	}
+	entries, err := p.snapshot(body.SnapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	// removed holds the keys of the entries to remove, which are looked up
+	// in the given snapshot and then removed from the current version.
+	removed := make(map[int]bool)
+	for _, t := range body.Tracks {
+		ids, err := s.trackIDs([]string{t.URI})
+		if err != nil {
+			return nil, err
+		}
+		if t.Positions == nil {
+			for _, e := range entries {
+				if e.track == ids[0] {
+					removed[e.key] = true
+				}
+			}
+			continue
+		}
+		for _, pos := range t.Positions {
+			if pos < 0 || pos >= len(entries) || entries[pos].track != ids[0] {
+				return nil, errorf(http.StatusBadRequest, "Could not remove tracks, please check parameters.")
+			}
+			removed[entries[pos].key] = true
+		}
+	}
+
+	var kept []entry
+	for _, e := range p.entries {
+		if !removed[e.key] {
+			kept = append(kept, e)
+		}
+	}
+	p.entries = kept
+	p.commit()
+	return respond(snapshot(p)), nil
+}
+
+func (s *Server) replacePlaylistTracks(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	uris := strings.Split(r.query.Get("uris"), ",")
+	if len(uris) > 100 {
+		return nil, errorf(http.StatusBadRequest, "You can add a maximum of 100 tracks per request.")
+	}
+	ids, err := s.trackIDs(uris)
+	if err != nil {
+		return nil, err
+	}
+	p.entries = nil
+	for _, id := range ids {
+		p.entries = append(p.entries, s.newEntry(p, id))
+	}
+	p.commit()
+	return &response{status: http.StatusCreated, body: snapshot(p)}, nil
+}
+
+func (s *Server) reorderPlaylistTracks(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	body := spotify.PlaylistReorderOptions{RangeLength: 1}
+	if err := r.decode(&body); err != nil {
+		return nil, err
+	}
+	if _, err := p.snapshot(body.SnapshotID); err != nil {
+		return nil, err
+	}
+	n := len(p.entries)
+	start, length, before := body.RangeStart, body.RangeLength, body.InsertBefore
+	if start < 0 || length < 1 || start+length > n || before < 0 || before > n {
+		return nil, errorf(http.StatusBadRequest, "Index out of bounds")
+	}
+
+	moved := append([]entry(nil), p.entries[start:start+length]...)
+	rest := append(append([]entry(nil), p.entries[:start]...), p.entries[start+length:]...)
+	if before > start {
+		before -= length
+		if before < start {
+			before = start
+		}
+	}
+	entries := append([]entry(nil), rest[:before]...)
+	entries = append(entries, moved...)
+	p.entries = append(entries, rest[before:]...)
+	p.commit()
+	return respond(snapshot(p)), nil
+}
+
+func (s *Server) setPlaylistImage(r *request, p *playlist) (*response, *requestError) {
+	if err := s.checkOwner(p); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "Error reading image")
+	}
+	if len(data) > 256*1024 {
+		return nil, errorf(http.StatusRequestEntityTooLarge, "Image is too large")
+	}
+	if _, err := base64.StdEncoding.DecodeString(string(data)); err != nil || len(data) == 0 {
+		return nil, errorf(http.StatusBadRequest, "Image must be base64 encoded")
+	}
+	p.images = []spotify.Image{{URL: "data:image/jpeg;base64," + string(data)}}
+	return &response{status: http.StatusAccepted}, nil
+}
+
+// userPlaylists lists the playlists followed by a user.  The private
+// playlists are only listed for the current user.
+func (s *Server) userPlaylists(r *request, userID string) (*response, *requestError) {
+	var playlists []spotify.SimplePlaylist
+	for _, id := range s.playlistOrder {
+		p := s.playlists[id]
+		public, ok := p.followers[userID]
+		if ok && (userID == s.user.ID || public && p.public) {
+			playlists = append(playlists, s.simplePlaylist(p))
+		}
+	}
+	pg, start, end, err := s.newPage(r, len(playlists), 20, 50)
+	if err != nil {
+		return nil, err
+	}
+	pg.Items = playlists[start:end]
+	return respond(pg), nil
+}
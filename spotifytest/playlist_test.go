@@ -0,0 +1,176 @@
+package spotifytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+func playlistTrackIDs(t *testing.T, client *spotify.Client, id spotify.ID) []spotify.ID {
+	t.Helper()
+	page, err := client.GetPlaylistTracks(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []spotify.ID
+	for _, track := range page.Tracks {
+		ids = append(ids, track.Track.ID)
+	}
+	return ids
+}
+
+func equalIDs(a []spotify.ID, b ...spotify.ID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestServerPlaylistLifecycle(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+
+	playlist, err := client.CreatePlaylistForUser(ctx, "test-user", "Road trip", "Songs for the car", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if playlist.Owner.ID != "test-user" || playlist.SnapshotID == "" {
+		t.Errorf("Unexpected playlist %+v", playlist)
+	}
+
+	first, err := client.AddTracksToPlaylist(ctx, playlist.ID, "track1", "track2", "track3", "track1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == playlist.SnapshotID {
+		t.Error("Expected a new snapshot ID")
+	}
+	if _, err := client.AddTracksToPlaylist(ctx, playlist.ID, "unknown"); err == nil {
+		t.Error("Expected an error when adding an unknown track")
+	}
+
+	if _, err := client.ReorderPlaylistTracks(ctx, playlist.ID, spotify.PlaylistReorderOptions{RangeStart: 0, InsertBefore: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if ids := playlistTrackIDs(t, client, playlist.ID); !equalIDs(ids, "track2", "track3", "track1", "track1") {
+		t.Errorf("Unexpected tracks after reordering: %v", ids)
+	}
+
+	// positions refer to the snapshot taken before reordering
+	_, err = client.RemoveTracksFromPlaylistOpt(ctx, playlist.ID, []spotify.TrackToRemove{
+		spotify.NewTrackToRemove("track1", []int{0}),
+	}, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := playlistTrackIDs(t, client, playlist.ID); !equalIDs(ids, "track2", "track3", "track1") {
+		t.Errorf("Unexpected tracks after removing a position: %v", ids)
+	}
+
+	if _, err := client.RemoveTracksFromPlaylist(ctx, playlist.ID, "track2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ReplacePlaylistTracks(ctx, playlist.ID, "track4", "track3"); err != nil {
+		t.Fatal(err)
+	}
+	if ids := playlistTrackIDs(t, client, playlist.ID); !equalIDs(ids, "track4", "track3") {
+		t.Errorf("Unexpected tracks after replacing: %v", ids)
+	}
+
+	if err := client.ChangePlaylistNameAccessAndDescription(ctx, playlist.ID, "Holiday", "New description", false); err != nil {
+		t.Fatal(err)
+	}
+	full, err := client.GetPlaylist(ctx, playlist.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Name != "Holiday" || full.Description != "New description" || full.IsPublic || full.Tracks.Total != 2 {
+		t.Errorf("Unexpected playlist %+v", full)
+	}
+}
+
+func TestServerPlaylistFollowers(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+	srv.AddUsers(spotify.User{ID: "someone-else"})
+
+	owned := srv.CreatePlaylist("Mine", true, "track1")
+	srv.SetCurrentUser(spotify.PrivateUser{User: spotify.User{ID: "someone-else"}, Product: "premium"})
+	theirs := srv.CreatePlaylist("Theirs", false)
+	srv.SetCurrentUser(spotify.PrivateUser{User: spotify.User{ID: "test-user"}, Product: "premium"})
+
+	if err := client.FollowPlaylist(ctx, theirs, false); err != nil {
+		t.Fatal(err)
+	}
+	page, err := client.CurrentUsersPlaylists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 2 || page.Playlists[0].ID != owned || page.Playlists[1].ID != theirs {
+		t.Errorf("Unexpected playlists %+v", page.Playlists)
+	}
+
+	follows, err := client.UserFollowsPlaylist(ctx, theirs, "test-user", "someone-else")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !follows[0] || follows[1] {
+		t.Errorf("Expected private follows to only be visible to the current user, got %v", follows)
+	}
+
+	err = client.ChangePlaylistName(ctx, theirs, "Stolen")
+	if !errors.Is(err, spotify.ErrForbidden) {
+		t.Errorf("Expected ErrForbidden when modifying another user's playlist, got %v", err)
+	}
+
+	if err := client.UnfollowPlaylist(ctx, theirs); err != nil {
+		t.Fatal(err)
+	}
+	page, err = client.GetPlaylistsForUser(ctx, "test-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 {
+		t.Errorf("Expected 1 playlist, got %d", page.Total)
+	}
+}
+
+func TestServerLargePlaylistPaging(t *testing.T) {
+	srv, client := newTestServer(t)
+	ctx := context.Background()
+
+	tracks := make([]spotify.ID, 250)
+	for i := range tracks {
+		tracks[i] = spotify.ID("track" + string(rune('1'+i%3)))
+	}
+	id := srv.CreatePlaylist("Long", true, tracks...)
+
+	playlist, err := client.GetPlaylist(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(playlist.Tracks.Tracks) != 100 || playlist.Tracks.Total != 250 {
+		t.Fatalf("Expected the first 100 of 250 tracks, got %d of %d", len(playlist.Tracks.Tracks), playlist.Tracks.Total)
+	}
+	page := playlist.Tracks
+	if err := client.NextPage(ctx, &page); err != nil {
+		t.Fatal(err)
+	}
+	if page.Offset != 100 || len(page.Tracks) != 100 || page.Tracks[0].Track.ID != tracks[100] {
+		t.Errorf("Unexpected second page: offset %d, %d tracks", page.Offset, len(page.Tracks))
+	}
+
+	if err := client.FetchAll(ctx, &playlist.Tracks); err != nil {
+		t.Fatal(err)
+	}
+	if len(playlist.Tracks.Tracks) != 250 {
+		t.Errorf("Expected 250 tracks, got %d", len(playlist.Tracks.Tracks))
+	}
+}
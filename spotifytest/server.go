@@ -0,0 +1,628 @@
+package spotifytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+// Server is a fake implementation of the Spotify Web API that keeps its state
+// in memory.  It serves the catalog it is seeded with, and supports the
+// playlist, library, follow, search and player endpoints used by the spotify
+// package, so that whole scenarios can be tested offline:
+//
+//	srv := spotifytest.NewServer()
+//	defer srv.Close()
+//	srv.AddTracks(track)
+//	client := spotify.New(spotify.WithBaseURL(srv.BaseURL()))
+//
+// Every request acts on behalf of the same current user, whatever
+// access token it carries.  Requests to endpoints the server doesn't
+// implement receive a 404.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	nextID int
+	user   spotify.PrivateUser
+	users  map[string]spotify.User
+
+	tracks      map[spotify.ID]spotify.FullTrack
+	trackOrder  []spotify.ID
+	albums      map[spotify.ID]spotify.FullAlbum
+	albumOrder  []spotify.ID
+	artists     map[spotify.ID]spotify.FullArtist
+	artistOrder []spotify.ID
+
+	playlists     map[spotify.ID]*playlist
+	playlistOrder []spotify.ID
+
+	savedTracks     []saved
+	savedAlbums     []saved
+	followedArtists []spotify.ID
+	followedUsers   []spotify.ID
+
+	devices []spotify.PlayerDevice
+	player  player
+}
+
+// saved is an item in the current user's library.
+type saved struct {
+	id      spotify.ID
+	addedAt time.Time
+}
+
+// NewServer starts a Server with an empty catalog.  The current user is
+// a premium user with the ID "test-user".  The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		users:     make(map[string]spotify.User),
+		tracks:    make(map[spotify.ID]spotify.FullTrack),
+		albums:    make(map[spotify.ID]spotify.FullAlbum),
+		artists:   make(map[spotify.ID]spotify.FullArtist),
+		playlists: make(map[spotify.ID]*playlist),
+		player:    player{repeat: "off"},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.SetCurrentUser(spotify.PrivateUser{
+		User: spotify.User{
+			ID:          "test-user",
+			DisplayName: "Test User",
+		},
+		Country: "US",
+		Product: "premium",
+	})
+	return s
+}
+
+// BaseURL returns the URL to pass to spotify.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v1/"
+}
+
+// SetCurrentUser sets the user on whose behalf every request is made.
+func (s *Server) SetCurrentUser(user spotify.PrivateUser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fillUser(&user.User)
+	s.user = user
+	s.users[user.ID] = user.User
+}
+
+// AddUsers adds users whose public profiles can be fetched and followed.
+func (s *Server) AddUsers(users ...spotify.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range users {
+		s.fillUser(&u)
+		s.users[u.ID] = u
+	}
+}
+
+// AddTracks adds tracks to the catalog.  A track belongs to the album its
+// Album field refers to, and tracks are listed on their album in the
+// order of their disc and track numbers.
+func (s *Server) AddTracks(tracks ...spotify.FullTrack) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range tracks {
+		if _, ok := s.tracks[t.ID]; !ok {
+			s.trackOrder = append(s.trackOrder, t.ID)
+		}
+		if t.URI == "" {
+			t.URI = spotify.NewURI(spotify.KindTrack, t.ID)
+		}
+		if t.Endpoint == "" {
+			t.Endpoint = s.BaseURL() + "tracks/" + string(t.ID)
+		}
+		if t.Type == "" {
+			t.Type = "track"
+		}
+		s.tracks[t.ID] = t
+	}
+}
+
+// AddAlbums adds albums to the catalog.  The tracks of an album
+// are the tracks of the catalog that belong to it.
+func (s *Server) AddAlbums(albums ...spotify.FullAlbum) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range albums {
+		if _, ok := s.albums[a.ID]; !ok {
+			s.albumOrder = append(s.albumOrder, a.ID)
+		}
+		if a.URI == "" {
+			a.URI = spotify.NewURI(spotify.KindAlbum, a.ID)
+		}
+		if a.Endpoint == "" {
+			a.Endpoint = s.BaseURL() + "albums/" + string(a.ID)
+		}
+		s.albums[a.ID] = a
+	}
+}
+
+// AddArtists adds artists to the catalog.
+func (s *Server) AddArtists(artists ...spotify.FullArtist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range artists {
+		if _, ok := s.artists[a.ID]; !ok {
+			s.artistOrder = append(s.artistOrder, a.ID)
+		}
+		if a.URI == "" {
+			a.URI = spotify.NewURI(spotify.KindArtist, a.ID)
+		}
+		if a.Endpoint == "" {
+			a.Endpoint = s.BaseURL() + "artists/" + string(a.ID)
+		}
+		s.artists[a.ID] = a
+	}
+}
+
+// AddDevices adds devices that playback can be transferred to.  If one of
+// them is active, it becomes the device controlled by the player endpoints.
+func (s *Server) AddDevices(devices ...spotify.PlayerDevice) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range devices {
+		if d.Active {
+			s.player.device = d.ID
+		}
+		d.Active = false
+		s.devices = append(s.devices, d)
+	}
+}
+
+func (s *Server) fillUser(u *spotify.User) {
+	if u.URI == "" {
+		u.URI = spotify.NewURI(spotify.KindUser, spotify.ID(u.ID))
+	}
+	if u.Endpoint == "" {
+		u.Endpoint = s.BaseURL() + "users/" + u.ID
+	}
+}
+
+// newID returns a new 22 character ID.
+func (s *Server) newID() spotify.ID {
+	s.nextID++
+	return spotify.ID(fmt.Sprintf("%022d", s.nextID))
+}
+
+// requestError is an error returned by the Web API.
+type requestError struct {
+	status  int
+	reason  string
+	message string
+}
+
+func errorf(status int, format string, args ...interface{}) *requestError {
+	return &requestError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+var (
+	errNotFound       = errorf(http.StatusNotFound, "Service not found")
+	errNoActiveDevice = &requestError{http.StatusNotFound, spotify.ReasonNoActiveDevice, "Player command failed: No active device found"}
+	errPremium        = &requestError{http.StatusForbidden, spotify.ReasonPremiumRequired, "Player command failed: Premium required"}
+)
+
+// request is an API request being served.  path holds the segments of the
+// request's path, after the version prefix.
+type request struct {
+	*http.Request
+	path  []string
+	query url.Values
+}
+
+// decode decodes the JSON body of the request into v.
+func (r *request) decode(v interface{}) *requestError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errorf(http.StatusBadRequest, "Error parsing JSON.")
+	}
+	return nil
+}
+
+// ids returns the comma separated IDs of the "ids" query parameter.
+func (r *request) ids(max int) ([]spotify.ID, *requestError) {
+	v := r.query.Get("ids")
+	if v == "" {
+		return nil, errorf(http.StatusBadRequest, "Missing required field: ids")
+	}
+	var ids []spotify.ID
+	for _, id := range strings.Split(v, ",") {
+		ids = append(ids, spotify.ID(id))
+	}
+	if len(ids) > max {
+		return nil, errorf(http.StatusBadRequest, "Too many ids requested")
+	}
+	return ids, nil
+}
+
+// response is what a handler returns: a status and the value to encode
+// as the body, if any.
+type response struct {
+	status int
+	body   interface{}
+}
+
+func respond(body interface{}) *response {
+	return &response{status: http.StatusOK, body: body}
+}
+
+func noContent() *response {
+	return &response{status: http.StatusNoContent}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &request{Request: r, query: r.URL.Query()}
+	path := strings.TrimPrefix(r.URL.Path, "/v1")
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			req.path = append(req.path, segment)
+		}
+	}
+
+	s.mu.Lock()
+	resp, err := s.route(req)
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(err.status)
+		var body struct {
+			Error struct {
+				Status  int    `json:"status"`
+				Message string `json:"message"`
+				Reason  string `json:"reason,omitempty"`
+			} `json:"error"`
+		}
+		body.Error.Status = err.status
+		body.Error.Message = err.message
+		body.Error.Reason = err.reason
+		_ = json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	w.WriteHeader(resp.status)
+	if resp.body != nil {
+		_ = json.NewEncoder(w).Encode(resp.body)
+	}
+}
+
+// route calls the handler of the endpoint that r is sent to.
+func (s *Server) route(r *request) (*response, *requestError) {
+	p := r.path
+	if len(p) == 0 {
+		return nil, errNotFound
+	}
+	switch p[0] {
+	case "tracks", "albums", "artists", "users", "search":
+		if r.Method == http.MethodGet || p[0] == "users" {
+			return s.routeCatalog(r)
+		}
+	case "playlists":
+		return s.routePlaylists(r)
+	case "me":
+		if len(p) == 1 && r.Method == http.MethodGet {
+			return respond(s.user), nil
+		}
+		switch p[1] {
+		case "player":
+			return s.routePlayer(r)
+		case "playlists":
+			if len(p) == 2 && r.Method == http.MethodGet {
+				return s.userPlaylists(r, s.user.ID)
+			}
+		case "tracks", "albums", "following":
+			return s.routeLibrary(r)
+		}
+	}
+	return nil, errNotFound
+}
+
+func (s *Server) routeCatalog(r *request) (*response, *requestError) {
+	p := r.path
+	switch {
+	case p[0] == "search" && len(p) == 1:
+		return s.search(r)
+	case p[0] == "users" && len(p) == 2 && r.Method == http.MethodGet:
+		u, ok := s.users[p[1]]
+		if !ok {
+			return nil, errorf(http.StatusNotFound, "No such user")
+		}
+		return respond(u), nil
+	case p[0] == "users" && len(p) == 3 && p[2] == "playlists":
+		switch r.Method {
+		case http.MethodGet:
+			return s.userPlaylists(r, p[1])
+		case http.MethodPost:
+			return s.createPlaylist(r, p[1])
+		}
+	case len(p) == 1 && p[0] != "users":
+		ids, err := r.ids(50)
+		if err != nil {
+			return nil, err
+		}
+		switch p[0] {
+		case "tracks":
+			tracks := make([]*spotify.FullTrack, len(ids))
+			for i, id := range ids {
+				if t, ok := s.tracks[id]; ok {
+					tracks[i] = &t
+				}
+			}
+			return respond(map[string]interface{}{"tracks": tracks}), nil
+		case "albums":
+			albums := make([]*spotify.FullAlbum, len(ids))
+			for i, id := range ids {
+				if _, ok := s.albums[id]; ok {
+					albums[i] = s.album(id)
+				}
+			}
+			return respond(map[string]interface{}{"albums": albums}), nil
+		case "artists":
+			artists := make([]*spotify.FullArtist, len(ids))
+			for i, id := range ids {
+				if a, ok := s.artists[id]; ok {
+					artists[i] = &a
+				}
+			}
+			return respond(map[string]interface{}{"artists": artists}), nil
+		}
+	case p[0] == "tracks" && len(p) == 2:
+		if t, ok := s.tracks[spotify.ID(p[1])]; ok {
+			return respond(t), nil
+		}
+		return nil, errorf(http.StatusNotFound, "non existing id")
+	case p[0] == "albums":
+		id := spotify.ID(p[1])
+		if _, ok := s.albums[id]; !ok {
+			return nil, errorf(http.StatusNotFound, "non existing id")
+		}
+		if len(p) == 2 {
+			return respond(s.album(id)), nil
+		}
+		if len(p) == 3 && p[2] == "tracks" {
+			tracks := s.albumTracks(id)
+			pg, start, end, err := s.newPage(r, len(tracks), 20, 50)
+			if err != nil {
+				return nil, err
+			}
+			pg.Items = tracks[start:end]
+			return respond(pg), nil
+		}
+	case p[0] == "artists":
+		id := spotify.ID(p[1])
+		a, found := s.artists[id]
+		if !found {
+			return nil, errorf(http.StatusNotFound, "non existing id")
+		}
+		if len(p) == 2 {
+			return respond(a), nil
+		}
+		if len(p) == 3 && p[2] == "albums" {
+			var albums []spotify.SimpleAlbum
+			for _, albumID := range s.albumOrder {
+				if album := s.albums[albumID]; hasArtist(album.Artists, id) {
+					albums = append(albums, album.SimpleAlbum)
+				}
+			}
+			pg, start, end, err := s.newPage(r, len(albums), 20, 50)
+			if err != nil {
+				return nil, err
+			}
+			pg.Items = albums[start:end]
+			return respond(pg), nil
+		}
+		if len(p) == 3 && p[2] == "top-tracks" {
+			var tracks []spotify.FullTrack
+			for _, trackID := range s.trackOrder {
+				if t := s.tracks[trackID]; hasArtist(t.Artists, id) {
+					tracks = append(tracks, t)
+				}
+			}
+			sort.SliceStable(tracks, func(i, j int) bool {
+				return tracks[i].Popularity > tracks[j].Popularity
+			})
+			if len(tracks) > 10 {
+				tracks = tracks[:10]
+			}
+			return respond(map[string]interface{}{"tracks": tracks}), nil
+		}
+	}
+	return nil, errNotFound
+}
+
+func hasArtist(artists []spotify.SimpleArtist, id spotify.ID) bool {
+	for _, a := range artists {
+		if a.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// album returns an album of the catalog along with its tracks.
+func (s *Server) album(id spotify.ID) *spotify.FullAlbum {
+	album := s.albums[id]
+	tracks := s.albumTracks(id)
+	album.Tracks.Tracks = tracks
+	album.Tracks.Total = len(tracks)
+	album.Tracks.Limit = len(tracks)
+	album.Tracks.Endpoint = s.BaseURL() + "albums/" + string(id) + "/tracks"
+	return &album
+}
+
+// albumTracks returns the tracks of the catalog that belong to an album.
+func (s *Server) albumTracks(id spotify.ID) []spotify.SimpleTrack {
+	var tracks []spotify.SimpleTrack
+	for _, trackID := range s.trackOrder {
+		if t := s.tracks[trackID]; t.Album.ID == id {
+			tracks = append(tracks, t.SimpleTrack)
+		}
+	}
+	sort.SliceStable(tracks, func(i, j int) bool {
+		if tracks[i].DiscNumber != tracks[j].DiscNumber {
+			return tracks[i].DiscNumber < tracks[j].DiscNumber
+		}
+		return tracks[i].TrackNumber < tracks[j].TrackNumber
+	})
+	return tracks
+}
+
+// search matches items whose name, or the name of one of whose artists,
+// contains every word of the query.  Field filters such as "artist:" are
+// ignored, but their values are still matched.
+func (s *Server) search(r *request) (*response, *requestError) {
+	q := r.query.Get("q")
+	if q == "" {
+		return nil, errorf(http.StatusBadRequest, "No search query")
+	}
+	var words []string
+	for _, word := range strings.Fields(strings.ToLower(q)) {
+		if i := strings.Index(word, ":"); i >= 0 {
+			word = word[i+1:]
+		}
+		if word = strings.Trim(word, `"`); word != "" {
+			words = append(words, word)
+		}
+	}
+	matches := func(names ...string) bool {
+		text := strings.ToLower(strings.Join(names, " "))
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				return false
+			}
+		}
+		return true
+	}
+	artistNames := func(artists []spotify.SimpleArtist) []string {
+		var names []string
+		for _, a := range artists {
+			names = append(names, a.Name)
+		}
+		return names
+	}
+
+	types := r.query.Get("type")
+	if types == "" {
+		return nil, errorf(http.StatusBadRequest, "Missing parameter type")
+	}
+	result := make(map[string]interface{})
+	for _, typ := range strings.Split(types, ",") {
+		var items []interface{}
+		switch typ {
+		case "track":
+			for _, id := range s.trackOrder {
+				t := s.tracks[id]
+				if matches(append(artistNames(t.Artists), t.Name, t.Album.Name)...) {
+					items = append(items, t)
+				}
+			}
+		case "album":
+			for _, id := range s.albumOrder {
+				a := s.albums[id]
+				if matches(append(artistNames(a.Artists), a.Name)...) {
+					items = append(items, a.SimpleAlbum)
+				}
+			}
+		case "artist":
+			for _, id := range s.artistOrder {
+				if a := s.artists[id]; matches(a.Name) {
+					items = append(items, a)
+				}
+			}
+		case "playlist":
+			for _, id := range s.playlistOrder {
+				if p := s.playlists[id]; p.public && matches(p.name) {
+					items = append(items, s.simplePlaylist(p))
+				}
+			}
+		default:
+			return nil, errorf(http.StatusBadRequest, "Bad search type field %s", typ)
+		}
+		pg, start, end, err := s.newPage(r, len(items), 20, 50)
+		if err != nil {
+			return nil, err
+		}
+		pg.Items = items[start:end]
+		result[typ+"s"] = pg
+	}
+	return respond(result), nil
+}
+
+// page is an offset-based paging object.
+type page struct {
+	Endpoint string      `json:"href"`
+	Items    interface{} `json:"items"`
+	Limit    int         `json:"limit"`
+	Offset   int         `json:"offset"`
+	Total    int         `json:"total"`
+	Next     *string     `json:"next"`
+	Previous *string     `json:"previous"`
+}
+
+// newPage parses the limit and offset of r and returns a paging object over
+// total items, along with the bounds of the items that belong in it.
+func (s *Server) newPage(r *request, total, defaultLimit, maxLimit int) (pg *page, start, end int, err *requestError) {
+	limit, offset := defaultLimit, 0
+	if v := r.query.Get("limit"); v != "" {
+		n, convErr := strconv.Atoi(v)
+		if convErr != nil || n < 1 || n > maxLimit {
+			return nil, 0, 0, errorf(http.StatusBadRequest, "Invalid limit")
+		}
+		limit = n
+	}
+	if v := r.query.Get("offset"); v != "" {
+		n, convErr := strconv.Atoi(v)
+		if convErr != nil || n < 0 {
+			return nil, 0, 0, errorf(http.StatusBadRequest, "Invalid offset")
+		}
+		offset = n
+	}
+
+	start, end = offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	link := func(offset int) *string {
+		v := url.Values{}
+		for k, vs := range r.query {
+			v[k] = vs
+		}
+		v.Set("offset", strconv.Itoa(offset))
+		v.Set("limit", strconv.Itoa(limit))
+		u := s.URL + r.URL.Path + "?" + v.Encode()
+		return &u
+	}
+	pg = &page{
+		Endpoint: s.URL + r.URL.RequestURI(),
+		Limit:    limit,
+		Offset:   offset,
+		Total:    total,
+	}
+	if offset+limit < total {
+		pg.Next = link(offset + limit)
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		pg.Previous = link(prev)
+	}
+	return pg, start, end, nil
+}
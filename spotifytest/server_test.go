@@ -0,0 +1,194 @@
+package spotifytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/conradludgate/spotify/v2"
+)
+
+// newTestServer returns a server seeded with one artist, one album of
+// three tracks and a single track from another artist, and a client for it.
+func newTestServer(t *testing.T) (*Server, *spotify.Client) {
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	artist := spotify.SimpleArtist{ID: "artist1", Name: "The Beatles"}
+	album := spotify.SimpleAlbum{ID: "album1", Name: "Abbey Road", Artists: []spotify.SimpleArtist{artist}}
+	srv.AddArtists(
+		spotify.FullArtist{SimpleArtist: artist},
+		spotify.FullArtist{SimpleArtist: spotify.SimpleArtist{ID: "artist2", Name: "Queen"}},
+	)
+	srv.AddAlbums(spotify.FullAlbum{SimpleAlbum: album})
+	for i, name := range []string{"Something", "Come Together", "Octopus's Garden"} {
+		srv.AddTracks(spotify.FullTrack{
+			SimpleTrack: spotify.SimpleTrack{
+				ID:          spotify.ID("track" + string(rune('1'+i))),
+				Name:        name,
+				Artists:     []spotify.SimpleArtist{artist},
+				Duration:    180000,
+				TrackNumber: 3 - i,
+			},
+			Album:      album,
+			Popularity: 50 + i,
+		})
+	}
+	srv.AddTracks(spotify.FullTrack{
+		SimpleTrack: spotify.SimpleTrack{
+			ID:      "track4",
+			Name:    "Bohemian Rhapsody",
+			Artists: []spotify.SimpleArtist{{ID: "artist2", Name: "Queen"}},
+		},
+	})
+
+	return srv, spotify.New(spotify.WithBaseURL(srv.BaseURL()))
+}
+
+func TestServerCatalog(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+
+	album, err := client.GetAlbum(ctx, "album1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(album.Tracks.Tracks) != 3 || album.Tracks.Tracks[0].ID != "track3" {
+		t.Errorf("Expected the album's tracks in track order, got %+v", album.Tracks.Tracks)
+	}
+
+	tracks, err := client.GetTracks(ctx, []spotify.ID{"track4", "missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tracks[0] == nil || tracks[0].Name != "Bohemian Rhapsody" || tracks[1] != nil {
+		t.Errorf("Unexpected tracks %v", tracks)
+	}
+
+	top, err := client.GetArtistsTopTracks(ctx, "artist1", "US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 3 || top[0].ID != "track3" {
+		t.Errorf("Expected the most popular track first, got %v", top)
+	}
+
+	_, err = client.GetTrack(ctx, "missing")
+	if !errors.Is(err, spotify.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServerSearch(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+
+	result, err := client.Search(ctx, "beatles", spotify.SearchTypeTrack|spotify.SearchTypeArtist, spotify.Limit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Artists == nil || len(result.Artists.Artists) != 1 {
+		t.Fatalf("Expected 1 artist, got %+v", result.Artists)
+	}
+	if result.Tracks == nil || result.Tracks.Total != 3 || len(result.Tracks.Tracks) != 2 {
+		t.Fatalf("Expected a page of 2 out of 3 tracks, got %+v", result.Tracks)
+	}
+	if err := client.NextTrackResults(ctx, result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks.Tracks) != 1 {
+		t.Errorf("Expected the last track on the next page, got %+v", result.Tracks.Tracks)
+	}
+
+	result, err = client.Search(ctx, "artist:queen rhapsody", spotify.SearchTypeTrack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tracks.Tracks) != 1 || result.Tracks.Tracks[0].ID != "track4" {
+		t.Errorf("Unexpected tracks %+v", result.Tracks.Tracks)
+	}
+}
+
+func TestServerLibrary(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+
+	if err := client.AddTracksToLibrary(ctx, "track1", "track4"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddTracksToLibrary(ctx, "nope"); err == nil {
+		t.Error("Expected an error when saving an unknown track")
+	}
+	has, err := client.UserHasTracks(ctx, "track1", "track2", "track4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !has[0] || has[1] || !has[2] {
+		t.Errorf("Unexpected result %v", has)
+	}
+
+	if err := client.RemoveTracksFromLibrary(ctx, "track1"); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := client.CurrentUsersTracks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Total != 1 || saved.Tracks[0].ID != "track4" || saved.Tracks[0].AddedAt == "" {
+		t.Errorf("Unexpected saved tracks %+v", saved.Tracks)
+	}
+
+	if err := client.AddAlbumsToLibrary(ctx, "album1"); err != nil {
+		t.Fatal(err)
+	}
+	albums, err := client.CurrentUsersAlbums(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(albums.Albums) != 1 || len(albums.Albums[0].Tracks.Tracks) != 3 {
+		t.Errorf("Unexpected saved albums %+v", albums.Albums)
+	}
+}
+
+func TestServerFollow(t *testing.T) {
+	_, client := newTestServer(t)
+	ctx := context.Background()
+
+	if err := client.FollowArtist(ctx, "artist1", "artist2"); err != nil {
+		t.Fatal(err)
+	}
+	follows, err := client.CurrentUserFollows(ctx, "artist", "artist2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !follows[0] {
+		t.Error("Expected artist2 to be followed")
+	}
+
+	page, err := client.CurrentUsersFollowedArtists(ctx, spotify.Limit(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []spotify.ID
+	it := client.Iterate(ctx, page)
+	for it.Next() {
+		ids = append(ids, it.Item().(spotify.FullArtist).ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "artist1" || ids[1] != "artist2" {
+		t.Errorf("Unexpected followed artists %v", ids)
+	}
+
+	if err := client.UnfollowArtist(ctx, "artist1"); err != nil {
+		t.Fatal(err)
+	}
+	page, err = client.CurrentUsersFollowedArtists(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 1 {
+		t.Errorf("Expected 1 followed artist, got %d", page.Total)
+	}
+}
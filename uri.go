@@ -0,0 +1,156 @@
+package spotify
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Kind is the type of item a URI identifies.
+type Kind string
+
+// Kinds of items that have a URI.
+const (
+	KindTrack     Kind = "track"
+	KindAlbum     Kind = "album"
+	KindArtist    Kind = "artist"
+	KindPlaylist  Kind = "playlist"
+	KindShow      Kind = "show"
+	KindEpisode   Kind = "episode"
+	KindAudiobook Kind = "audiobook"
+	KindChapter   Kind = "chapter"
+	KindUser      Kind = "user"
+)
+
+// kinds are the kinds ParseURI recognizes.
+var kinds = []Kind{KindTrack, KindAlbum, KindArtist, KindPlaylist, KindShow, KindEpisode, KindAudiobook, KindChapter, KindUser}
+
+// idLength is the length of base-62 IDs.
+const idLength = 22
+
+// Resource identifies an item of the Spotify catalog, or a user.
+type Resource struct {
+	Kind Kind
+	// ID is the base-62 ID of the item, or the ID of the user.
+	ID ID
+	// Owner is the ID of the user owning a playlist, when it was given
+	// by a legacy user-scoped playlist URI such as
+	// spotify:user:spotify:playlist:37i9dQZF1DXcBWIGoYBM5M.
+	Owner string
+}
+
+// NewURI returns the URI of the item of the given kind with the given ID,
+// such as spotify:track:6rqhFgbbKwnb9MLmUQDhG6.
+func NewURI(kind Kind, id ID) URI {
+	return URI("spotify:" + string(kind) + ":" + string(id))
+}
+
+// ValidID reports whether id is a well-formed base-62 ID.
+func ValidID(id ID) bool {
+	if len(id) != idLength {
+		return false
+	}
+	for _, r := range id {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseURI parses any of the ways to refer to an item of the catalog or a user:
+//
+//	spotify:track:6rqhFgbbKwnb9MLmUQDhG6
+//	spotify:user:spotify:playlist:37i9dQZF1DXcBWIGoYBM5M
+//	https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6?si=0123456789abcdef
+//	https://open.spotify.com/intl-fr/album/1DFixLWuPkv3KT3TnV35m3
+//	https://api.spotify.com/v1/artists/0OdUWJ0sBjDrqHygGUXeCF
+//
+// The IDs of items other than users must be valid base-62 IDs.
+func ParseURI(s string) (Resource, error) {
+	var r Resource
+	var ok bool
+	if strings.HasPrefix(s, "spotify:") {
+		r, ok = parseSegments(strings.Split(strings.TrimPrefix(s, "spotify:"), ":"))
+	} else if u, err := url.Parse(s); err == nil && (u.Scheme == "https" || u.Scheme == "http") {
+		r, ok = parseURL(u)
+	}
+	if !ok {
+		return Resource{}, fmt.Errorf("spotify: %q is not a Spotify URI or URL", s)
+	}
+	if r.Kind != KindUser && !ValidID(r.ID) {
+		return Resource{}, fmt.Errorf("spotify: invalid ID %q in %q", r.ID, s)
+	}
+	return r, nil
+}
+
+func parseURL(u *url.URL) (Resource, bool) {
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i, seg := range segments {
+		var err error
+		if segments[i], err = url.PathUnescape(seg); err != nil {
+			return Resource{}, false
+		}
+	}
+	switch u.Host {
+	case "open.spotify.com", "play.spotify.com":
+		if strings.HasPrefix(segments[0], "intl-") {
+			segments = segments[1:]
+		}
+		if len(segments) > 0 && segments[0] == "embed" {
+			segments = segments[1:]
+		}
+		return parseSegments(segments)
+	case "api.spotify.com":
+		if len(segments) != 3 || segments[0] != "v1" {
+			return Resource{}, false
+		}
+		kind := Kind(strings.TrimSuffix(segments[1], "s"))
+		if kind+"s" != Kind(segments[1]) {
+			return Resource{}, false
+		}
+		return parseSegments([]string{string(kind), segments[2]})
+	}
+	return Resource{}, false
+}
+
+// parseSegments parses the segments of a URI or the path of an open URL,
+// such as ["track", "6rqhFgbbKwnb9MLmUQDhG6"].
+func parseSegments(segments []string) (Resource, bool) {
+	if len(segments) == 4 && segments[0] == string(KindUser) && segments[2] == string(KindPlaylist) && segments[1] != "" {
+		r, ok := parseSegments(segments[2:])
+		r.Owner = segments[1]
+		return r, ok
+	}
+	if len(segments) != 2 || segments[1] == "" {
+		return Resource{}, false
+	}
+	for _, k := range kinds {
+		if segments[0] == string(k) {
+			return Resource{Kind: k, ID: ID(segments[1])}, true
+		}
+	}
+	return Resource{}, false
+}
+
+// URI returns the URI of the resource, such as spotify:track:6rqhFgbbKwnb9MLmUQDhG6.
+func (r Resource) URI() URI {
+	return NewURI(r.Kind, r.ID)
+}
+
+// URL returns the address of the resource in the Spotify web player,
+// such as https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6.
+func (r Resource) URL() string {
+	return "https://open.spotify.com/" + string(r.Kind) + "/" + url.PathEscape(string(r.ID))
+}
+
+// Href returns the Web API endpoint providing the full details of the
+// resource, such as https://api.spotify.com/v1/tracks/6rqhFgbbKwnb9MLmUQDhG6.
+func (r Resource) Href() string {
+	return "https://api.spotify.com/v1/" + string(r.Kind) + "s/" + url.PathEscape(string(r.ID))
+}
+
+// Parse is a shorthand for ParseURI(string(uri)).
+func (uri URI) Parse() (Resource, error) {
+	return ParseURI(string(uri))
+}
@@ -0,0 +1,85 @@
+package spotify
+
+import "testing"
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Resource
+	}{
+		{"spotify:track:6rqhFgbbKwnb9MLmUQDhG6", Resource{Kind: KindTrack, ID: "6rqhFgbbKwnb9MLmUQDhG6"}},
+		{"spotify:episode:512ojhOuo1ktJprKbVcKyQ", Resource{Kind: KindEpisode, ID: "512ojhOuo1ktJprKbVcKyQ"}},
+		{"spotify:audiobook:7iHfbu1YPACw6oZPAFJtqe", Resource{Kind: KindAudiobook, ID: "7iHfbu1YPACw6oZPAFJtqe"}},
+		{"https://open.spotify.com/chapter/0D5wENdkdwbqlrHoaJ9g29", Resource{Kind: KindChapter, ID: "0D5wENdkdwbqlrHoaJ9g29"}},
+		{"spotify:user:spotify:playlist:37i9dQZF1DXcBWIGoYBM5M", Resource{Kind: KindPlaylist, ID: "37i9dQZF1DXcBWIGoYBM5M", Owner: "spotify"}},
+		{"spotify:user:john.doe", Resource{Kind: KindUser, ID: "john.doe"}},
+		{"https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6?si=0123456789abcdef", Resource{Kind: KindTrack, ID: "6rqhFgbbKwnb9MLmUQDhG6"}},
+		{"https://open.spotify.com/intl-fr/album/1DFixLWuPkv3KT3TnV35m3", Resource{Kind: KindAlbum, ID: "1DFixLWuPkv3KT3TnV35m3"}},
+		{"https://open.spotify.com/embed/show/38bS44xjbVVZ3No3ByF1dJ", Resource{Kind: KindShow, ID: "38bS44xjbVVZ3No3ByF1dJ"}},
+		{"https://open.spotify.com/user/spotify/playlist/37i9dQZF1DXcBWIGoYBM5M", Resource{Kind: KindPlaylist, ID: "37i9dQZF1DXcBWIGoYBM5M", Owner: "spotify"}},
+		{"https://open.spotify.com/user/john%20doe", Resource{Kind: KindUser, ID: "john doe"}},
+		{"https://api.spotify.com/v1/artists/0OdUWJ0sBjDrqHygGUXeCF", Resource{Kind: KindArtist, ID: "0OdUWJ0sBjDrqHygGUXeCF"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseURI(tt.in)
+		if err != nil {
+			t.Errorf("ParseURI(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseURI(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseURIErrors(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"6rqhFgbbKwnb9MLmUQDhG6",
+		"spotify:track:",
+		"spotify:track:too-short",
+		"spotify:track:6rqhFgbbKwnb9MLmUQDhG6:extra",
+		"spotify:podcast:6rqhFgbbKwnb9MLmUQDhG6",
+		"https://example.com/track/6rqhFgbbKwnb9MLmUQDhG6",
+		"https://open.spotify.com/track",
+		"https://api.spotify.com/v1/me/tracks",
+		"https://api.spotify.com/v1/track/6rqhFgbbKwnb9MLmUQDhG6",
+		"ftp://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6",
+	} {
+		if r, err := ParseURI(in); err == nil {
+			t.Errorf("ParseURI(%q) = %+v, expected an error", in, r)
+		}
+	}
+}
+
+func TestResourceForms(t *testing.T) {
+	r := Resource{Kind: KindTrack, ID: "6rqhFgbbKwnb9MLmUQDhG6"}
+	if uri := r.URI(); uri != "spotify:track:6rqhFgbbKwnb9MLmUQDhG6" {
+		t.Errorf("Unexpected URI %s", uri)
+	}
+	if u := r.URL(); u != "https://open.spotify.com/track/6rqhFgbbKwnb9MLmUQDhG6" {
+		t.Errorf("Unexpected URL %s", u)
+	}
+	if href := r.Href(); href != "https://api.spotify.com/v1/tracks/6rqhFgbbKwnb9MLmUQDhG6" {
+		t.Errorf("Unexpected href %s", href)
+	}
+	for _, s := range []string{string(r.URI()), r.URL(), r.Href()} {
+		if got, err := ParseURI(s); err != nil || got != r {
+			t.Errorf("Expected %s to parse back to %+v, got %+v, %v", s, r, got, err)
+		}
+	}
+	if got, err := URI("spotify:user:alice").Parse(); err != nil || got.Kind != KindUser || got.ID != "alice" {
+		t.Errorf("Unexpected %+v, %v", got, err)
+	}
+}
+
+func TestValidID(t *testing.T) {
+	if !ValidID("6rqhFgbbKwnb9MLmUQDhG6") {
+		t.Error("Expected a base-62 ID to be valid")
+	}
+	for _, id := range []ID{"", "6rqhFgbbKwnb9MLmUQDhG", "6rqhFgbbKwnb9MLmUQDhG6a", "6rqhFgbbKwnb9MLmUQDh-6"} {
+		if ValidID(id) {
+			t.Errorf("Expected %q to be invalid", id)
+		}
+	}
+}
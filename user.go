@@ -114,6 +114,51 @@ func (c *Client) CurrentUsersShows(ctx context.Context, opts ...RequestOption) (
 	return &result, nil
 }
 
+// CurrentUsersAudiobooks gets a list of audiobooks saved in the current
+// Spotify user's library.  This call requires the ScopeUserLibraryRead scope.
+//
+// API Doc: https://developer.spotify.com/documentation/web-api/reference/get-users-saved-audiobooks
+//
+// Supported options: Limit, Offset
+func (c *Client) CurrentUsersAudiobooks(ctx context.Context, opts ...RequestOption) (*SimpleAudiobookPage, error) {
+	spotifyURL := c.baseURL + "me/audiobooks"
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result SimpleAudiobookPage
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CurrentUsersEpisodes gets a list of episodes saved in the current
+// Spotify user's "Your Episodes" library.  This call requires the
+// ScopeUserLibraryRead and ScopeUserReadPlaybackPosition scopes.
+//
+// API Doc: https://developer.spotify.com/documentation/web-api/reference/#endpoint-get-users-saved-episodes
+//
+// Supported options: Limit, Market, Offset
+func (c *Client) CurrentUsersEpisodes(ctx context.Context, opts ...RequestOption) (*SavedEpisodePage, error) {
+	spotifyURL := c.baseURL + "me/episodes"
+	if params := processOptions(opts...).urlParams.Encode(); params != "" {
+		spotifyURL += "?" + params
+	}
+
+	var result SavedEpisodePage
+
+	err := c.get(ctx, spotifyURL, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // CurrentUsersTracks gets a list of songs saved in the current
 // Spotify user's "Your Music" library.
 //
@@ -235,18 +280,25 @@ func (c *Client) CurrentUsersFollowedArtists(ctx context.Context, opts ...Reques
 		spotifyURL += "?" + params
 	}
 
-	var result struct {
-		A FullArtistCursorPage `json:"artists"`
-	}
+	var result followedArtists
 
 	err := c.get(ctx, spotifyURL, &result)
 	if err != nil {
 		return nil, err
 	}
+	result.A.newWrapper = func() wrappedPage { return new(followedArtists) }
 
 	return &result.A, nil
 }
 
+// followedArtists is the object that the current user's followed artists
+// are wrapped in.
+type followedArtists struct {
+	A FullArtistCursorPage `json:"artists"`
+}
+
+func (f *followedArtists) unwrapPage() pageable { return &f.A }
+
 // CurrentUsersAlbums gets a list of albums saved in the current
 // Spotify user's "Your Music" library.
 //